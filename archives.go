@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// archiveIndex records the entries of an expanded tar archive.
+type archiveIndex struct {
+	files map[string]*tar.Header
+	dirs  map[string]struct{}
+}
+
+// archiveFor returns the expanded archive that contains the path name and
+// the path of name inside the archive. The archive itself is ".".
+func (rfs *ReleaseFileSystem) archiveFor(name string) (*AssetFile, string, bool) {
+	for _, archive := range rfs.Options.ExpandArchives {
+		i, ok := rfs.Release.fileIndex[archive]
+		if !ok || !rfs.visible(archive) {
+			continue
+		}
+		a := rfs.Release.Assets[i]
+		if name == a.Path() {
+			return a, ".", true
+		}
+		if inner, ok := strings.CutPrefix(name, a.Path()+"/"); ok {
+			return a, inner, true
+		}
+	}
+	return nil, "", false
+}
+
+// isExpanded returns true if the asset is an archive expanded into the
+// filesystem namespace.
+func (rfs *ReleaseFileSystem) isExpanded(a *AssetFile) bool {
+	return slices.Contains(rfs.Options.ExpandArchives, a.Name())
+}
+
+// archiveIndex returns the index of an expanded archive, reading the
+// archive the first time it is accessed.
+func (rfs *ReleaseFileSystem) archiveIndex(ctx context.Context, a *AssetFile) (*archiveIndex, error) {
+	rfs.archivesMtx.Lock()
+	defer rfs.archivesMtx.Unlock()
+
+	if idx, ok := rfs.archives[a.Name()]; ok {
+		return idx, nil
+	}
+
+	tr, src, err := rfs.openTar(ctx, a.Name())
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close() //nolint:errcheck
+
+	idx := &archiveIndex{files: map[string]*tar.Header{}, dirs: map[string]struct{}{}}
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive %q: %w", a.Name(), err)
+		}
+
+		name, ok := tarEntryPath(h)
+		if !ok {
+			continue
+		}
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			idx.dirs[dir] = struct{}{}
+		}
+		switch h.Typeflag {
+		case tar.TypeDir:
+			idx.dirs[name] = struct{}{}
+		case tar.TypeReg:
+			idx.files[name] = h
+		}
+	}
+
+	if rfs.archives == nil {
+		rfs.archives = map[string]*archiveIndex{}
+	}
+	rfs.archives[a.Name()] = idx
+	return idx, nil
+}
+
+// openTar opens an archive asset for reading its entries. Archives ending
+// in .gz or .tgz are decompressed.
+func (rfs *ReleaseFileSystem) openTar(ctx context.Context, name string) (*tar.Reader, *AssetFile, error) {
+	f, err := rfs.openVerifiedAsset(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if strings.HasSuffix(name, ".gz") || strings.HasSuffix(name, ".tgz") {
+		if err := f.decompress(); err != nil {
+			f.Close() //nolint:errcheck,gosec
+			return nil, nil, fmt.Errorf("decompressing %q: %w", name, err)
+		}
+	}
+	return tar.NewReader(f), f, nil
+}
+
+// tarEntryPath returns the cleaned path of a tar entry. Entries with paths
+// that escape the archive are ignored.
+func tarEntryPath(h *tar.Header) (string, bool) {
+	name := path.Clean(h.Name)
+	if name == "." || !fs.ValidPath(name) {
+		return "", false
+	}
+	return name, true
+}
+
+// archiveFileInfo returns the file info of an archive entry.
+func archiveFileInfo(h *tar.Header) FileInfo {
+	return FileInfo{
+		IName: path.Base(path.Clean(h.Name)),
+		ISize: h.Size,
+		Ctime: h.ModTime,
+		Mtime: h.ModTime,
+	}
+}
+
+// statArchive returns the file info of a path inside an expanded archive.
+func (rfs *ReleaseFileSystem) statArchive(ctx context.Context, a *AssetFile, inner string) (fs.FileInfo, error) {
+	if inner == "." {
+		return rfs.subdir(a.Path(), nil).Info()
+	}
+
+	idx, err := rfs.archiveIndex(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	if h, ok := idx.files[inner]; ok {
+		return archiveFileInfo(h), nil
+	}
+	if _, ok := idx.dirs[inner]; ok {
+		return rfs.subdir(inner, nil).Info()
+	}
+	return nil, fs.ErrNotExist
+}
+
+// archiveDirEntries lists a directory inside an expanded archive.
+func (rfs *ReleaseFileSystem) archiveDirEntries(ctx context.Context, a *AssetFile, inner string) ([]fs.DirEntry, error) {
+	idx, err := rfs.archiveIndex(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := idx.dirs[inner]; !ok && inner != "." {
+		return nil, fs.ErrNotExist
+	}
+
+	ret := []fs.DirEntry{}
+	for name, h := range idx.files {
+		if path.Dir(name) == inner {
+			ret = append(ret, &AssetFile{
+				DisplayPath: path.Join(a.Path(), name),
+				FileInfo:    archiveFileInfo(h),
+			})
+		}
+	}
+	for name := range idx.dirs {
+		if path.Dir(name) == inner {
+			ret = append(ret, rfs.subdir(name, nil))
+		}
+	}
+
+	slices.SortFunc(ret, compareEntries)
+	return ret, nil
+}
+
+// openArchive opens a path inside an expanded archive. Files are streamed
+// from the archive, which is read up to the entry.
+func (rfs *ReleaseFileSystem) openArchive(ctx context.Context, a *AssetFile, inner string) (fs.File, error) {
+	info, err := rfs.statArchive(ctx, a, inner)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		entries, err := rfs.archiveDirEntries(ctx, a, inner)
+		if err != nil {
+			return nil, err
+		}
+		return rfs.subdir(path.Join(a.Path(), inner), entries), nil
+	}
+
+	tr, src, err := rfs.openTar(ctx, a.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		h, err := tr.Next()
+		if err != nil {
+			src.Close() //nolint:errcheck,gosec
+			if errors.Is(err, io.EOF) {
+				return nil, fs.ErrNotExist
+			}
+			return nil, fmt.Errorf("reading archive %q: %w", a.Name(), err)
+		}
+
+		if name, ok := tarEntryPath(h); ok && name == inner && h.Typeflag == tar.TypeReg {
+			return &AssetFile{
+				DataStream:  &readCloser{Reader: tr, Closer: src},
+				source:      src.Source(),
+				DisplayPath: path.Join(a.Path(), name),
+				FileInfo:    archiveFileInfo(h),
+			}, nil
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// tarGz returns a gzipped tar archive with the files, directories are
+// entries ending with a slash.
+func tarGz(t *testing.T, entries []string, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, name := range entries {
+		if name[len(name)-1] == '/' {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeDir, Mode: 0o755}))
+			continue
+		}
+		data := files[name]
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(data)),
+		}))
+		_, err := tw.Write([]byte(data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestExpandArchives(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"./top.txt":         "top",
+		"foo/bar.txt":       "bar",
+		"nested/deep/x.txt": "deep",
+		"../escape.txt":     "nope",
+	}
+	bundle := tarGz(t, []string{"./top.txt", "foo/", "foo/bar.txt", "nested/deep/x.txt", "../escape.txt"}, files)
+
+	newFS := func(t *testing.T) *ReleaseFileSystem {
+		t.Helper()
+		rfs := newCachedTestFS(t, map[string][]byte{
+			"bundle.tar.gz": bundle,
+			"notes.txt":     []byte("notes"),
+		})
+		rfs.Options.ExpandArchives = []string{"bundle.tar.gz"}
+		return rfs
+	}
+
+	t.Run("read", func(t *testing.T) {
+		t.Parallel()
+		rfs := newFS(t)
+		for name, expect := range map[string]string{
+			"bundle.tar.gz/top.txt":           "top",
+			"bundle.tar.gz/foo/bar.txt":       "bar",
+			"bundle.tar.gz/nested/deep/x.txt": "deep",
+			"notes.txt":                       "notes",
+		} {
+			data, err := fs.ReadFile(rfs, name)
+			require.NoError(t, err, name)
+			require.Equal(t, expect, string(data))
+		}
+
+		info, err := rfs.Stat("bundle.tar.gz")
+		require.NoError(t, err)
+		require.True(t, info.IsDir())
+
+		for _, name := range []string{"bundle.tar.gz/missing.txt", "bundle.tar.gz/foo/missing", "escape.txt"} {
+			_, err := rfs.Open(name)
+			require.ErrorIs(t, err, fs.ErrNotExist, name)
+		}
+	})
+
+	t.Run("walk", func(t *testing.T) {
+		t.Parallel()
+		rfs := newFS(t)
+		visited := []string{}
+		require.NoError(t, fs.WalkDir(rfs, ".", func(path string, d fs.DirEntry, err error) error {
+			visited = append(visited, path)
+			return err
+		}))
+		require.Equal(t, []string{
+			".", "bundle.tar.gz", "bundle.tar.gz/foo", "bundle.tar.gz/foo/bar.txt",
+			"bundle.tar.gz/nested", "bundle.tar.gz/nested/deep", "bundle.tar.gz/nested/deep/x.txt",
+			"bundle.tar.gz/top.txt", "notes.txt",
+		}, visited)
+	})
+
+	t.Run("fstest", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, fstest.TestFS(newFS(t), "bundle.tar.gz/foo/bar.txt", "notes.txt"))
+	})
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const attestationsURLMask = `repos/%s/%s/attestations/sha256:%x?per_page=100`
+
+// Attestation is an attestation stored in GitHub for an asset.
+type Attestation struct {
+	// Bundle is the raw sigstore bundle of the attestation
+	Bundle       json.RawMessage `json:"bundle"`
+	RepositoryID int64           `json:"repository_id"`
+	// BundleURL points to the bundle when it is not returned inline
+	BundleURL string `json:"bundle_url,omitempty"`
+}
+
+// AssetAttestations returns the attestations stored in the repository for
+// the named asset, such as GitHub's build provenance attestations. The
+// attestations are looked up by the SHA-256 digest of the asset. The digest
+// reported by GitHub is used when available, otherwise the asset data is
+// read from the cache or downloaded to compute it. If the asset has no
+// attestations, an empty list is returned.
+func (rfs *ReleaseFileSystem) AssetAttestations(ctx context.Context, name string) ([]Attestation, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil, err
+	}
+
+	digest, err := rfs.assetSHA256(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []Attestation{}
+	next := fmt.Sprintf(attestationsURLMask, rfs.Options.Organization, rfs.Options.Repository, digest)
+	for next != "" {
+		var page []Attestation
+		page, next, err = rfs.attestationsPage(ctx, name, next)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, page...)
+	}
+	return ret, nil
+}
+
+// assetSHA256 returns the SHA-256 digest of the named asset, taken from the
+// digest reported by GitHub or computed from the asset data.
+func (rfs *ReleaseFileSystem) assetSHA256(ctx context.Context, name string) ([]byte, error) {
+	i, err := rfs.lookup(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "attestations", Path: name, Err: err}
+	}
+	if alg, digest, ok := parseDigest(rfs.Release.Assets[i].Digest()); ok && alg == crypto.SHA256 {
+		return digest, nil
+	}
+
+	digests, err := rfs.checksum(ctx, name, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("computing digest: %w", err)
+	}
+	return digests[crypto.SHA256], nil
+}
+
+// attestationsPage fetches a page of the attestations of an asset. It
+// returns the attestations and the endpoint of the next page, if any.
+func (rfs *ReleaseFileSystem) attestationsPage(ctx context.Context, name, endpoint string) ([]Attestation, string, error) {
+	ctx, cancel := withTimeout(ctx, rfs.Options.Timeout)
+	defer cancel()
+
+	resp, err := rfs.client.Call(ctx, "GET", endpoint, nil)
+	if resp != nil {
+		defer resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, "", nil
+		}
+		if resp.StatusCode > 399 || resp.StatusCode < 200 {
+			return nil, "", fmt.Errorf(
+				"HTTP error %d when getting attestations for %q: %s",
+				resp.StatusCode, name, errorMessage(resp, err),
+			)
+		}
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("getting attestations for %q: %w", name, err)
+	}
+
+	data := struct {
+		Attestations []Attestation `json:"attestations"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, "", fmt.Errorf("decoding attestations: %w", err)
+	}
+	return data.Attestations, nextPageLink(resp.Header), nil
+}
+
+// nextPageLink returns the endpoint of the next page listed in the Link
+// header of a paginated API response, or an empty string on the last page.
+// Only the path and query are returned so the request goes to the
+// configured API host.
+func nextPageLink(h http.Header) string {
+	for _, link := range strings.Split(h.Get("Link"), ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return ""
+		}
+		return strings.TrimPrefix(u.RequestURI(), "/")
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/carabiner-dev/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetAttestations(t *testing.T) {
+	t.Parallel()
+	data := []byte("binary data")
+	endpoint := fmt.Sprintf("repos/example/repo/attestations/sha256:%x?per_page=100", sha256.Sum256(data))
+
+	for _, tc := range []struct {
+		name     string
+		response *fakeResponse
+		expect   int
+		mustErr  bool
+	}{
+		{
+			"found",
+			&fakeResponse{http.StatusOK, `{"attestations":[{"bundle":{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json"},"repository_id":1234}]}`},
+			1, false,
+		},
+		{"none", nil, 0, false},
+		{"error", &fakeResponse{http.StatusInternalServerError, "boom"}, 0, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs, caller := newRemoteTestFS(t, map[string][]byte{"tool": data})
+			if tc.response != nil {
+				caller.responses[endpoint] = *tc.response
+			}
+			client, err := github.NewClient(github.WithCaller(caller))
+			require.NoError(t, err)
+			rfs.client = client
+
+			atts, err := rfs.AssetAttestations(t.Context(), "tool")
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, atts, tc.expect)
+			if tc.expect > 0 {
+				require.Equal(t, int64(1234), atts[0].RepositoryID)
+				require.JSONEq(t, `{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json"}`, string(atts[0].Bundle))
+			}
+		})
+	}
+
+	t.Run("digest", func(t *testing.T) {
+		t.Parallel()
+		rfs, caller := newRemoteTestFS(t, map[string][]byte{"tool": data})
+		rfs.Release.Assets[0].IDigest = fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+		client, err := github.NewClient(github.WithCaller(caller))
+		require.NoError(t, err)
+		rfs.client = client
+		caller.responses[endpoint] = fakeResponse{http.StatusOK, `{"attestations":[{"repository_id":1}]}`}
+
+		// The digest reported by GitHub is used, the asset is not downloaded
+		atts, err := rfs.AssetAttestations(t.Context(), "tool")
+		require.NoError(t, err)
+		require.Len(t, atts, 1)
+		require.Equal(t, []string{endpoint}, caller.requests)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		t.Parallel()
+		rfs, caller := newRemoteTestFS(t, map[string][]byte{"tool": data})
+		rfs.Release.Assets[0].IDigest = fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+		client, err := github.NewClient(github.WithCaller(caller))
+		require.NoError(t, err)
+		rfs.client = client
+		const next = "repositories/1/attestations/sha256:abc?per_page=100&after=cursor"
+		caller.responses[endpoint] = fakeResponse{http.StatusOK, `{"attestations":[{"repository_id":1}]}`}
+		caller.responses[next] = fakeResponse{http.StatusOK, `{"attestations":[{"repository_id":2}]}`}
+		caller.headers = map[string]http.Header{endpoint: {
+			"Link": []string{`<https://api.github.com/` + next + `>; rel="next"`},
+		}}
+
+		atts, err := rfs.AssetAttestations(t.Context(), "tool")
+		require.NoError(t, err)
+		require.Len(t, atts, 2)
+		require.Equal(t, int64(2), atts[1].RepositoryID)
+		require.Equal(t, []string{endpoint, next}, caller.requests)
+	})
+
+	// Unknown assets fail
+	rfs, _ := newRemoteTestFS(t, map[string][]byte{"tool": data})
+	_, err := rfs.AssetAttestations(t.Context(), "missing")
+	require.Error(t, err)
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+)
+
+// Cache is a store for the data of cached release assets. Implement it to
+// keep the cache somewhere other than the local disk, for example in an
+// object store shared by several workers, and set it with WithCacheBackend.
+type Cache interface {
+	// Get returns a reader for the named file and true, or false if the
+	// file is not in the cache.
+	Get(name string) (io.ReadCloser, bool)
+
+	// Put stores the data read from r under name, replacing any previous
+	// copy. If reading r fails, Put must return the error and not keep a
+	// partial copy.
+	Put(name string, r io.Reader) error
+
+	// Stat returns the information of the named file. It returns an error
+	// wrapping fs.ErrNotExist if the file is not in the cache.
+	Stat(name string) (fs.FileInfo, error)
+}
+
+// backendKey returns the name of an asset in the cache backend
+func (rfs *ReleaseFileSystem) backendKey(name string) (string, error) {
+	sanitize := rfs.Options.NameSanitizer
+	if sanitize == nil {
+		sanitize = SanitizeName
+	}
+	return sanitize(name)
+}
+
+// openBackendFile opens an asset from the cache backend, falling back to
+// fetching it remotely using ctx when it is not found.
+func (rfs *ReleaseFileSystem) openBackendFile(ctx context.Context, i int) (*AssetFile, error) {
+	asset := rfs.Release.Assets[i]
+	key, err := rfs.backendKey(asset.Name())
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", asset.Name(), err)
+	}
+
+	// Check with the server that the cached copy is still current
+	if rfs.Options.Revalidate {
+		if err := rfs.revalidateCached(ctx, asset); err != nil {
+			rfs.logger().DebugContext(ctx, "cache miss", "asset", asset.Name(), "reason", "revalidation failed", "error", err)
+			return rfs.cacheMiss(ctx, asset.Name(), "revalidation failed")
+		}
+	}
+
+	info, err := rfs.Options.CacheBackend.Stat(key)
+	if err != nil {
+		rfs.logger().DebugContext(ctx, "cache miss", "asset", asset.Name(), "reason", "not cached")
+		return rfs.cacheMiss(ctx, asset.Name(), "not cached")
+	}
+
+	// A size mismatch means the cached copy is truncated or corrupted
+	if asset.Size() >= 0 && info.Size() != asset.Size() {
+		if rfs.Options.StrictCache {
+			return nil, fmt.Errorf(
+				"cached file %q is %d bytes, expected %d", asset.Name(), info.Size(), asset.Size(),
+			)
+		}
+		rfs.logger().DebugContext(
+			ctx, "cache miss", "asset", asset.Name(), "reason", "size mismatch",
+			"size", info.Size(), "expected", asset.Size(),
+		)
+		return rfs.cacheMiss(ctx, asset.Name(), "size mismatch")
+	}
+
+	rc, ok := rfs.Options.CacheBackend.Get(key)
+	if !ok {
+		rfs.logger().DebugContext(ctx, "cache miss", "asset", asset.Name(), "reason", "not cached")
+		return rfs.cacheMiss(ctx, asset.Name(), "not cached")
+	}
+	rfs.logger().DebugContext(ctx, "cache hit", "asset", asset.Name(), "size", info.Size())
+
+	return &AssetFile{
+		DataStream:   verifyBackendStream(rc, asset),
+		source:       SourceCache,
+		FileInfo:     asset.FileInfo,
+		URL:          asset.URL,
+		APIURL:       asset.APIURL,
+		DisplayPath:  asset.DisplayPath,
+		ID:           asset.ID,
+		NodeID:       asset.NodeID,
+		ETag:         asset.ETag,
+		LastModified: asset.LastModified,
+	}, nil
+}
+
+// verifyBackendStream checks the data read from the cache backend against
+// the digest reported by GitHub or, if there is none, the digest recorded
+// when the asset was cached. Objects in shared stores can change after they
+// are written, so they are checked as they are read: when the data ends,
+// reading returns an error wrapping ErrDigestMismatch if it does not match.
+func verifyBackendStream(rc io.ReadCloser, a *AssetFile) io.ReadCloser {
+	digest := a.Digest()
+	alg, expected, ok := parseDigest(digest)
+	if !ok {
+		digest = a.CacheDigest
+		if alg, expected, ok = parseDigest(digest); !ok {
+			return rc
+		}
+	}
+	return &readCloser{
+		Reader: &digestReader{Reader: rc, hash: alg.New(), name: a.Name(), digest: digest, expected: expected},
+		Closer: rc,
+	}
+}
+
+// cacheBackendAsset stores the data of an asset in the cache backend. The
+// data is verified before it is stored and checked against the asset digest
+// as it is written.
+func (rfs *ReleaseFileSystem) cacheBackendAsset(ctx context.Context, a *AssetFile) error {
+	key, err := rfs.backendKey(a.Name())
+	if err != nil {
+		return fmt.Errorf("caching %q: %w", a.Name(), err)
+	}
+
+	src := a
+	if a.DataStream == nil {
+		src, err = rfs.openRemoteFile(ctx, a.Name())
+		if err != nil {
+			return fmt.Errorf("caching %q: %w", a.Name(), err)
+		}
+	}
+	defer src.Close() //nolint:errcheck
+	recordValidators(a, src)
+
+	// Backends commit the data as soon as it is read, run the verifier
+	// before handing it over.
+	if err := rfs.verifyAsset(ctx, src); err != nil {
+		return err
+	}
+
+	a.CacheDigest = ""
+	dr := &digestReader{Reader: src, hash: sha256.New(), name: a.Name()}
+	if alg, expected, ok := parseDigest(a.Digest()); ok && alg == crypto.SHA256 {
+		dr.digest, dr.expected = a.Digest(), expected
+	}
+	if err := rfs.Options.CacheBackend.Put(key, dr); err != nil {
+		return fmt.Errorf("caching %q: %w", a.Name(), err)
+	}
+	if !dr.done {
+		return fmt.Errorf("caching %q: the cache backend did not read all the data", a.Name())
+	}
+	a.CacheDigest = "sha256:" + hex.EncodeToString(dr.hash.Sum(nil))
+	return nil
+}
+
+// digestReader hashes the data read from an asset. When the data ends, it
+// returns ErrDigestMismatch instead of io.EOF if the data does not match
+// the expected digest, if set, so the backend discards it.
+type digestReader struct {
+	io.Reader
+	hash     hash.Hash
+	name     string
+	digest   string
+	expected []byte
+	done     bool
+}
+
+func (dr *digestReader) Read(p []byte) (int, error) {
+	n, err := dr.Reader.Read(p)
+	dr.hash.Write(p[:n]) //nolint:errcheck,gosec // Hashes never fail
+	if !errors.Is(err, io.EOF) {
+		return n, err
+	}
+
+	dr.done = true
+	if dr.expected != nil {
+		if got := dr.hash.Sum(nil); !bytes.Equal(got, dr.expected) {
+			return n, fmt.Errorf(
+				"data of %q has digest %x, expected %s: %w", dr.name, got, dr.digest, ErrDigestMismatch,
+			)
+		}
+	}
+	return n, err
+}
+
+// putReleaseData stores the release data sidecar in the cache backend.
+func (rfs *ReleaseFileSystem) putReleaseData() error {
+	data, err := json.Marshal(rfs.Release) //nolint:musttag
+	if err != nil {
+		return fmt.Errorf("encoding release data: %w", err)
+	}
+	if err := rfs.Options.CacheBackend.Put(rfs.cacheMetadataName(), bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("storing release data: %w", err)
+	}
+	return nil
+}
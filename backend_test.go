@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBackend is a Cache that keeps files in a map
+type memoryBackend struct {
+	mtx   sync.Mutex
+	files map[string][]byte
+}
+
+func (mb *memoryBackend) Get(name string) (io.ReadCloser, bool) {
+	mb.mtx.Lock()
+	defer mb.mtx.Unlock()
+	data, ok := mb.files[name]
+	if !ok {
+		return nil, false
+	}
+	return io.NopCloser(bytes.NewReader(data)), true
+}
+
+func (mb *memoryBackend) Put(name string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	mb.mtx.Lock()
+	defer mb.mtx.Unlock()
+	mb.files[name] = data
+	return nil
+}
+
+func (mb *memoryBackend) Stat(name string) (fs.FileInfo, error) {
+	mb.mtx.Lock()
+	defer mb.mtx.Unlock()
+	data, ok := mb.files[name]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return FileInfo{IName: name, ISize: int64(len(data)), Mtime: time.Now()}, nil
+}
+
+func TestCacheBackend(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{"a.txt": []byte("hello"), "b.txt": []byte("bye")}
+	rfs, caller := newRemoteTestFS(t, files)
+	rfs.Release.Assets[0].IDigest = fmt.Sprintf("sha256:%x", sha256.Sum256(files[rfs.Release.Assets[0].Name()]))
+	backend := &memoryBackend{files: map[string][]byte{}}
+	require.NoError(t, WithCacheBackend(backend)(&rfs.Options))
+
+	require.NoError(t, rfs.CacheRelease())
+	require.Empty(t, rfs.Options.CachePath)
+	require.Contains(t, backend.files, rfs.cacheMetadataName())
+	for name, data := range files {
+		require.Equal(t, data, backend.files[name])
+	}
+
+	// Files are read from the backend without new downloads
+	requests := len(caller.requests)
+	for name, data := range files {
+		f, err := rfs.Open(name)
+		require.NoError(t, err)
+		require.Equal(t, SourceCache, f.(*AssetFile).Source())
+		got, err := io.ReadAll(f)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.Equal(t, data, got)
+	}
+	require.Len(t, caller.requests, requests)
+
+	// Truncated copies are a cache miss
+	backend.files["b.txt"] = []byte("b")
+	data, err := fs.ReadFile(rfs, "b.txt")
+	require.NoError(t, err)
+	require.Equal(t, files["b.txt"], data)
+	require.Len(t, caller.requests, requests+1)
+
+	// Copies corrupted in the backend fail to read
+	corrupted := bytes.ToUpper(files[rfs.Release.Assets[0].Name()])
+	backend.files[rfs.Release.Assets[0].Name()] = corrupted
+	_, err = fs.ReadFile(rfs, rfs.Release.Assets[0].Name())
+	require.ErrorIs(t, err, ErrDigestMismatch)
+
+	// Data not matching the digest is never stored
+	delete(backend.files, rfs.Release.Assets[0].Name())
+	rfs.Release.Assets[0].IDigest = fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("other")))
+	err = rfs.cacheAsset(t.Context(), rfs.Release.Assets[0])
+	require.ErrorIs(t, err, ErrDigestMismatch)
+	require.NotContains(t, backend.files, rfs.Release.Assets[0].Name())
+
+	// Only the disk cache can be synced
+	_, err = rfs.SyncCache(t.Context())
+	require.Error(t, err)
+}
+
+func TestCacheBackendVerifier(t *testing.T) {
+	t.Parallel()
+	data := []byte("signed data")
+	for _, tc := range []struct {
+		name    string
+		sig     string
+		mustErr bool
+	}{
+		{"valid", fmt.Sprintf("%x", sha256.Sum256(data)), false},
+		{"invalid", "bad", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs, _ := newRemoteTestFS(t, map[string][]byte{"data.txt": data, "data.txt.sig": []byte(tc.sig)})
+			rfs.Options.Verifier = digestVerifier{}
+			backend := &memoryBackend{files: map[string][]byte{}}
+			require.NoError(t, WithCacheBackend(backend)(&rfs.Options))
+
+			err := rfs.CacheRelease()
+			if tc.mustErr {
+				var verr *VerificationError
+				require.ErrorAs(t, err, &verr)
+				require.NotContains(t, backend.files, "data.txt")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, data, backend.files["data.txt"])
+		})
+	}
+}
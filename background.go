@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"sync"
+)
+
+// cacheWarming tracks a release being cached in the background.
+type cacheWarming struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+
+	// cached records the names of the assets cached so far
+	cached sync.Map
+}
+
+// ready returns true if the named asset can be read from the cache: it was
+// cached already or the warming is over. With no warming, the cache is
+// always ready.
+func (w *cacheWarming) ready(name string) bool {
+	if w == nil {
+		return true
+	}
+	if _, ok := w.cached.Load(name); ok {
+		return true
+	}
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// markCached records that an asset finished caching.
+func (w *cacheWarming) markCached(name string) {
+	if w != nil {
+		w.cached.Store(name, struct{}{})
+	}
+}
+
+// startCacheWarming caches the release in a goroutine. The cache directory
+// is prepared before returning so errors setting it up are not deferred.
+// The downloads are not bound to ctx, only its values are kept.
+func (rfs *ReleaseFileSystem) startCacheWarming(ctx context.Context) error {
+	if rfs.Options.CacheBackend == nil {
+		if err := rfs.prepareCacheDir(); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	w := &cacheWarming{cancel: cancel, done: make(chan struct{})}
+	rfs.warming = w
+	rfs.logger().DebugContext(ctx, "caching release in the background", "release", rfs.releaseRef())
+	go func() {
+		defer close(w.done)
+		defer cancel()
+		w.err = rfs.cacheRelease(ctx)
+		if w.err != nil {
+			rfs.logger().WarnContext(ctx, "background caching failed", "release", rfs.releaseRef(), "error", w.err)
+		}
+	}()
+	return nil
+}
+
+// WaitForCache blocks until the release caching started in the background
+// by WithBackgroundCache completes, returning its error. If ctx is done
+// first, it returns the context error and the caching continues. It returns
+// nil right away when the release is not being cached in the background.
+func (rfs *ReleaseFileSystem) WaitForCache(ctx context.Context) error {
+	w := rfs.warming
+	if w == nil {
+		return nil
+	}
+	select {
+	case <-w.done:
+		return w.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stopCacheWarming cancels the background caching, if any, and waits for
+// it to stop.
+func (rfs *ReleaseFileSystem) stopCacheWarming() {
+	if w := rfs.warming; w != nil {
+		w.cancel()
+		<-w.done
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackgroundCache(t *testing.T) {
+	t.Parallel()
+
+	// newFS returns a filesystem caching a.txt and b.txt in the background
+	// from a server that holds b.txt until unblock is closed.
+	newFS := func(t *testing.T, unblock chan struct{}) *ReleaseFileSystem {
+		t.Helper()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/b.txt" {
+				select {
+				case <-unblock:
+				case <-r.Context().Done():
+					return
+				}
+			}
+			io.WriteString(w, "data") //nolint:errcheck,gosec
+		}))
+		t.Cleanup(srv.Close)
+
+		rfs := &ReleaseFileSystem{Options: defaultOptions}
+		require.NoError(t, WithBackgroundCache(true)(&rfs.Options))
+		rfs.Options.CachePath = t.TempDir()
+		data := ReleaseData{Tag: "v1.0.0"}
+		for _, name := range []string{"a.txt", "b.txt"} {
+			data.Assets = append(data.Assets, &AssetFile{
+				URL: srv.URL + "/" + name, FileInfo: FileInfo{IName: name, ISize: 4},
+			})
+		}
+		require.NoError(t, rfs.setRelease(t.Context(), data))
+		return rfs
+	}
+
+	t.Run("wait", func(t *testing.T) {
+		t.Parallel()
+		unblock := make(chan struct{})
+		rfs := newFS(t, unblock)
+
+		// Loading returns while b.txt is still downloading
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, rfs.WaitForCache(ctx), context.DeadlineExceeded)
+		require.False(t, rfs.warming.ready("b.txt"))
+
+		close(unblock)
+		require.NoError(t, rfs.WaitForCache(t.Context()))
+		for _, name := range []string{"a.txt", "b.txt"} {
+			data, err := os.ReadFile(filepath.Join(rfs.Options.CachePath, name))
+			require.NoError(t, err)
+			require.Equal(t, "data", string(data))
+
+			f, err := rfs.Open(name)
+			require.NoError(t, err)
+			s, ok := f.(interface{ Source() Source })
+			require.True(t, ok)
+			require.Equal(t, SourceCache, s.Source())
+			require.NoError(t, f.Close())
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+		rfs := newFS(t, make(chan struct{}))
+		require.NoError(t, rfs.Close())
+		require.ErrorIs(t, rfs.WaitForCache(t.Context()), context.Canceled)
+	})
+
+	t.Run("not-running", func(t *testing.T) {
+		t.Parallel()
+		rfs := &ReleaseFileSystem{Options: defaultOptions}
+		require.NoError(t, rfs.WaitForCache(t.Context()))
+	})
+}
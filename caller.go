@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/carabiner-dev/github"
+)
+
+const (
+	defaultAPIVersion = "2022-11-28"
+	defaultUserAgent  = "ghrfs"
+)
+
+// tokenEnvVars are the environment variables read to look for a token, in
+// order of precedence.
+var tokenEnvVars = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+
+var (
+	_ github.Caller = (*httpCaller)(nil)
+	_ apiCaller     = (*github.Client)(nil)
+)
+
+// apiCaller is the interface used to call the GitHub API. It is satisfied
+// by *github.Client and lets tests replace the client with a fake.
+type apiCaller interface {
+	Call(ctx context.Context, method, path string, body io.Reader) (*http.Response, error)
+}
+
+// httpCaller implements github.Caller using a net/http client. Unlike the
+// stock caller in the github module, it lets us control the headers sent
+// with each request and the client used to send them.
+type httpCaller struct {
+	client     *http.Client
+	hostname   string
+	token      string
+	userAgent  string
+	apiVersion string
+
+	// accept overrides the media type requested from the server
+	accept string
+
+	// header holds extra headers sent with every request
+	header http.Header
+}
+
+// RequestWithContext sends a request to the server. Endpoints can be paths
+// relative to the API host or full URLs. The token is only sent when the
+// request goes to the configured host.
+func (hc *httpCaller) RequestWithContext(
+	ctx context.Context, method, endpoint string, body io.Reader,
+) (*http.Response, error) {
+	urlString := endpoint
+	sameHost := true
+	if strings.HasPrefix(endpoint, "https://") || strings.HasPrefix(endpoint, "http://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("parsing URL: %w", err)
+		}
+		sameHost = u.Hostname() == hc.hostname
+	} else {
+		urlString = fmt.Sprintf("https://%s/%s", hc.hostname, strings.TrimPrefix(endpoint, "/"))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, urlString, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	accept := hc.accept
+	if accept == "" {
+		accept = "application/vnd.github+json"
+	}
+	for k, v := range hc.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", accept)
+	apiVersion := hc.apiVersion
+	if apiVersion == "" {
+		apiVersion = defaultAPIVersion
+	}
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	req.Header.Set("User-Agent", hc.userAgent)
+	if hc.token != "" && sameHost {
+		req.Header.Set("Authorization", "Bearer "+hc.token)
+	}
+
+	resp, err := hc.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like the stock caller, return an error if the server returns an HTTP
+	// error. The body is left unread so callers can inspect it.
+	if resp.StatusCode < 200 || resp.StatusCode > 399 {
+		return resp, fmt.Errorf("HTTP error %d sending request", resp.StatusCode)
+	}
+	return resp, nil
+}
+
+// apiVersion returns the GitHub API version to request
+func (rfs *ReleaseFileSystem) apiVersion() string {
+	if rfs.Options.APIVersion == "" {
+		return defaultAPIVersion
+	}
+	return rfs.Options.APIVersion
+}
+
+// userAgent returns the User-Agent string to send in requests
+func (rfs *ReleaseFileSystem) userAgent() string {
+	if rfs.Options.UserAgent == "" {
+		return defaultUserAgent
+	}
+	return rfs.Options.UserAgent
+}
+
+// token returns the token to authenticate to GitHub. An explicit token takes
+// precedence, followed by the GITHUB_TOKEN and GH_TOKEN environment variables.
+// No token is returned when anonymous access is forced.
+func (rfs *ReleaseFileSystem) token() string {
+	if rfs.Options.Anonymous {
+		return ""
+	}
+	if rfs.Options.Token != "" {
+		return rfs.Options.Token
+	}
+	for _, v := range tokenEnvVars {
+		if t := os.Getenv(v); t != "" {
+			return t
+		}
+	}
+	return ""
+}
+
+// isGitHubHost returns true if hostname is the configured API host or one
+// of GitHub's hosts, the only ones we trust with the token.
+func (rfs *ReleaseFileSystem) isGitHubHost(hostname string) bool {
+	return hostname == rfs.Options.Host || hostname == "github.com" ||
+		strings.HasSuffix(hostname, ".github.com")
+}
+
+// newCaller returns the caller used to talk to a host. The token is
+// only sent to GitHub, never to mirrors or other hosts.
+func (rfs *ReleaseFileSystem) newCaller(hostname string) *httpCaller {
+	token := ""
+	if rfs.isGitHubHost(hostname) {
+		token = rfs.token()
+	}
+	return &httpCaller{
+		client:     rfs.httpClient(),
+		hostname:   hostname,
+		token:      token,
+		userAgent:  rfs.userAgent(),
+		apiVersion: rfs.apiVersion(),
+	}
+}
+
+// httpClient returns the HTTP client used to talk to GitHub and download
+// assets. It is built once from the configured client, proxy and TLS
+// settings.
+func (rfs *ReleaseFileSystem) httpClient() *http.Client {
+	rfs.httpClientOnce.Do(func() {
+		client := rfs.Options.HTTPClient
+		if client == nil {
+			client = http.DefaultClient
+		}
+		customTLS := rfs.Options.InsecureSkipVerify || rfs.Options.RootCAs != nil
+		if rfs.Options.Proxy == nil && !customTLS {
+			rfs.httpClientInstance = client
+			return
+		}
+
+		// Route the requests through the proxy and set the TLS options,
+		// keeping the rest of the transport configuration.
+		var transport *http.Transport
+		switch t := client.Transport.(type) {
+		case nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone() //nolint:errcheck,forcetypeassert
+		case *http.Transport:
+			transport = t.Clone()
+		default:
+			// Custom round trippers are responsible for their own proxying
+			// and TLS configuration
+			rfs.httpClientInstance = client
+			return
+		}
+		if rfs.Options.Proxy != nil {
+			transport.Proxy = http.ProxyURL(rfs.Options.Proxy)
+		}
+		if customTLS {
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{} //nolint:gosec // MinVersion is the Go default
+			}
+			if rfs.Options.RootCAs != nil {
+				transport.TLSClientConfig.RootCAs = rfs.Options.RootCAs
+			}
+			transport.TLSClientConfig.InsecureSkipVerify = rfs.Options.InsecureSkipVerify //nolint:gosec // Opt in
+		}
+
+		c := *client
+		c.Transport = transport
+		rfs.httpClientInstance = &c
+	})
+	return rfs.httpClientInstance
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAgent(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		opts   []optFunc
+		expect string
+	}{
+		{"default", nil, defaultUserAgent},
+		{"custom", []optFunc{WithUserAgent("my-bot/1.0")}, "my-bot/1.0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var mtx sync.Mutex
+			var got []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mtx.Lock()
+				got = append(got, r.Header.Get("User-Agent"))
+				mtx.Unlock()
+				w.Write([]byte("data")) //nolint:errcheck,gosec
+			}))
+			t.Cleanup(srv.Close)
+
+			opts := defaultOptions
+			for _, fn := range tc.opts {
+				require.NoError(t, fn(&opts))
+			}
+			rfs := &ReleaseFileSystem{Options: opts}
+			rfs.Release.Assets = []*AssetFile{
+				{URL: srv.URL + "/asset.txt", FileInfo: FileInfo{IName: "asset.txt", ISize: 4}},
+			}
+			rfs.indexAssets()
+
+			// Both the regular download and ranged reads send the agent
+			f, err := rfs.OpenRemoteFile("asset.txt")
+			require.NoError(t, err)
+			_, err = io.ReadAll(f)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			r, err := rfs.OpenRange("asset.txt", 1, 2)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+
+			require.Equal(t, []string{tc.expect, tc.expect}, got)
+		})
+	}
+}
+
+func TestHTTPCallerToken(t *testing.T) {
+	t.Parallel()
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	caller := &httpCaller{client: srv.Client(), hostname: "127.0.0.1", token: "secret", userAgent: "test"}
+	resp, err := caller.RequestWithContext(t.Context(), http.MethodGet, srv.URL+"/ok", nil)
+	require.NoError(t, err)
+	resp.Body.Close() //nolint:errcheck,gosec
+	require.Equal(t, "Bearer secret", auth)
+
+	// The token is not leaked to other hosts
+	caller.hostname = "api.github.com"
+	resp, err = caller.RequestWithContext(t.Context(), http.MethodGet, srv.URL+"/ok", nil)
+	require.NoError(t, err)
+	resp.Body.Close() //nolint:errcheck,gosec
+	require.Empty(t, auth)
+
+	// HTTP errors return the response along with the error
+	resp, err = caller.RequestWithContext(t.Context(), http.MethodGet, srv.URL+"/missing", nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close() //nolint:errcheck,gosec
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestToken(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		opts        Options
+		githubToken string
+		ghToken     string
+		expect      string
+	}{
+		{"none", Options{}, "", "", ""},
+		{"github-token", Options{}, "gh1", "gh2", "gh1"},
+		{"gh-token", Options{}, "", "gh2", "gh2"},
+		{"explicit", Options{Token: "explicit"}, "gh1", "gh2", "explicit"},
+		{"anonymous", Options{Token: "explicit", Anonymous: true}, "gh1", "gh2", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("GITHUB_TOKEN", tc.githubToken)
+			t.Setenv("GH_TOKEN", tc.ghToken)
+			tc.opts.Host = githubAPIURL
+			rfs := &ReleaseFileSystem{Options: tc.opts}
+			require.Equal(t, tc.expect, rfs.token())
+			require.Equal(t, tc.expect, rfs.newCaller(githubAPIURL).token)
+			require.Equal(t, tc.expect, rfs.newCaller("github.com").token)
+
+			// The token is never sent to other hosts
+			require.Empty(t, rfs.newCaller("mirror.example.com").token)
+		})
+	}
+}
+
+func TestProxy(t *testing.T) {
+	t.Parallel()
+	// The stub proxy serves the asset data for any absolute URL it receives
+	var mtx sync.Mutex
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		proxied = append(proxied, r.URL.String())
+		mtx.Unlock()
+		w.Write([]byte("data")) //nolint:errcheck,gosec
+	}))
+	t.Cleanup(proxy.Close)
+
+	opts := defaultOptions
+	require.NoError(t, WithProxy(proxy.URL)(&opts))
+	rfs := &ReleaseFileSystem{Options: opts}
+	const assetURL = "http://downloads.example.invalid/asset.txt"
+	rfs.Release.Assets = []*AssetFile{
+		{URL: assetURL, FileInfo: FileInfo{IName: "asset.txt", ISize: 4}},
+	}
+	rfs.indexAssets()
+
+	f, err := rfs.OpenRemoteFile("asset.txt")
+	require.NoError(t, err)
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, "data", string(data))
+
+	r, err := rfs.OpenRange("asset.txt", 0, 2)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	require.Equal(t, []string{assetURL, assetURL}, proxied)
+
+	// Invalid proxies are rejected
+	require.Error(t, WithProxy("ftp://proxy.example.com")(&opts))
+	require.Error(t, WithProxy("http://")(&opts))
+}
+
+func TestTLSOptions(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data")) //nolint:errcheck,gosec
+	}))
+	t.Cleanup(srv.Close)
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	for _, tc := range []struct {
+		name    string
+		opts    []optFunc
+		mustErr bool
+	}{
+		{"untrusted", nil, true},
+		{"root-cas", []optFunc{WithRootCAs(pool)}, false},
+		{"insecure", []optFunc{WithInsecureSkipVerify(true)}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			opts := defaultOptions
+			for _, fn := range tc.opts {
+				require.NoError(t, fn(&opts))
+			}
+			rfs := &ReleaseFileSystem{Options: opts}
+			rfs.Release.Assets = []*AssetFile{
+				{URL: srv.URL + "/asset.txt", FileInfo: FileInfo{IName: "asset.txt", ISize: 4}},
+			}
+			rfs.indexAssets()
+
+			// API calls
+			resp, err := rfs.newCaller("127.0.0.1").RequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+			if resp != nil {
+				resp.Body.Close() //nolint:errcheck,gosec
+			}
+			if tc.mustErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			// Asset downloads
+			f, err := rfs.OpenRemoteFile("asset.txt")
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			data, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+			require.Equal(t, "data", string(data))
+		})
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		version string
+		expect  string
+		mustErr bool
+	}{
+		{"default", "", defaultAPIVersion, false},
+		{"custom", "2026-03-10", "2026-03-10", false},
+		{"invalid", "v3", "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("X-GitHub-Api-Version")
+			}))
+			t.Cleanup(srv.Close)
+
+			opts := defaultOptions
+			if tc.version != "" {
+				err := WithAPIVersion(tc.version)(&opts)
+				if tc.mustErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+			}
+			rfs := &ReleaseFileSystem{Options: opts}
+
+			resp, err := rfs.newCaller("127.0.0.1").RequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+			require.NoError(t, err)
+			resp.Body.Close() //nolint:errcheck,gosec
+			require.Equal(t, tc.expect, got)
+		})
+	}
+}
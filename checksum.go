@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"crypto"
+	_ "crypto/sha256" // Register SHA-224 and SHA-256
+	_ "crypto/sha512" // Register the SHA-384 and SHA-512 variants
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+
+	"github.com/nozzle/throttler"
+)
+
+// Checksum computes the digests of an asset's data using the specified hash
+// algorithms, defaulting to SHA-256 when none are specified. The asset data
+// is read from the cache or from GitHub and it is streamed through all the
+// hashers in a single pass.
+func (rfs *ReleaseFileSystem) Checksum(name string, algs ...crypto.Hash) (map[crypto.Hash][]byte, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil, err
+	}
+
+	return rfs.checksum(context.Background(), name, algs...)
+}
+
+// checksum computes the asset digests using ctx to fetch the data.
+func (rfs *ReleaseFileSystem) checksum(ctx context.Context, name string, algs ...crypto.Hash) (map[crypto.Hash][]byte, error) {
+	if len(algs) == 0 {
+		algs = []crypto.Hash{crypto.SHA256}
+	}
+
+	hashers := map[crypto.Hash]hash.Hash{}
+	writers := make([]io.Writer, 0, len(algs))
+	for _, alg := range algs {
+		if !alg.Available() {
+			return nil, fmt.Errorf("hash algorithm %s is not available", alg)
+		}
+		if _, ok := hashers[alg]; ok {
+			continue
+		}
+		hashers[alg] = alg.New()
+		writers = append(writers, hashers[alg])
+	}
+
+	f, err := rfs.openAsset(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	if _, err := io.Copy(io.MultiWriter(writers...), f); err != nil {
+		return nil, fmt.Errorf("reading %q: %w", name, err)
+	}
+
+	ret := make(map[crypto.Hash][]byte, len(hashers))
+	for alg, h := range hashers {
+		ret[alg] = h.Sum(nil)
+	}
+	return ret, nil
+}
+
+// WriteChecksums computes the digest of every asset in the release using
+// alg and writes them to w in the format of sha256sum and similar tools,
+// one "<hex digest>  <name>" line per asset sorted by name. Assets are read
+// from the cache or downloaded `ParallelDownloads` at a time.
+func (rfs *ReleaseFileSystem) WriteChecksums(w io.Writer, alg crypto.Hash) error {
+	if err := rfs.ensureLoaded(); err != nil {
+		return err
+	}
+
+	if !alg.Available() {
+		return fmt.Errorf("hash algorithm %s is not available", alg)
+	}
+
+	names := make([]string, 0, len(rfs.Release.Assets))
+	for _, a := range rfs.Release.Assets {
+		if rfs.isCacheSidecar(a.Name()) {
+			continue
+		}
+		names = append(names, a.Name())
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	digests := make([][]byte, len(names))
+	t := throttler.New(rfs.parallelDownloads(len(names)), len(names))
+	for i, name := range names {
+		go func() {
+			sums, err := rfs.checksum(context.Background(), name, alg)
+			if err != nil {
+				t.Done(fmt.Errorf("hashing %q: %w", name, err))
+				return
+			}
+			digests[i] = sums[alg]
+			t.Done(nil)
+		}()
+		t.Throttle()
+	}
+	if err := errors.Join(t.Errs()...); err != nil {
+		return err
+	}
+
+	for i, name := range names {
+		if _, err := fmt.Fprintf(w, "%x  %s\n", digests[i], name); err != nil {
+			return fmt.Errorf("writing checksums: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+	data := []byte("Hello, release!\n")
+	sum256 := sha256.Sum256(data)
+	sum512 := sha512.Sum512(data)
+
+	for _, tc := range []struct {
+		name    string
+		asset   string
+		algs    []crypto.Hash
+		expect  map[crypto.Hash][]byte
+		mustErr error
+	}{
+		{"default", "test.txt", nil, map[crypto.Hash][]byte{crypto.SHA256: sum256[:]}, nil},
+		{
+			"multiple", "test.txt", []crypto.Hash{crypto.SHA256, crypto.SHA512},
+			map[crypto.Hash][]byte{crypto.SHA256: sum256[:], crypto.SHA512: sum512[:]}, nil,
+		},
+		{"missing", "nope.txt", nil, nil, fs.ErrNotExist},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := newCachedTestFS(t, map[string][]byte{"test.txt": data})
+			res, err := rfs.Checksum(tc.asset, tc.algs...)
+			if tc.mustErr != nil {
+				require.ErrorIs(t, err, tc.mustErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, res)
+		})
+	}
+}
+
+func TestWriteChecksums(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{
+		"zeta.txt":  []byte("zeta"),
+		"alpha.txt": []byte("alpha"),
+		"mid.bin":   []byte("mid"),
+	}
+	rfs := newCachedTestFS(t, files)
+
+	expect := ""
+	for _, name := range []string{"alpha.txt", "mid.bin", "zeta.txt"} {
+		expect += fmt.Sprintf("%x  %s\n", sha256.Sum256(files[name]), name)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, rfs.WriteChecksums(&buf, crypto.SHA256))
+	require.Equal(t, expect, buf.String())
+
+	require.Error(t, rfs.WriteChecksums(io.Discard, crypto.MD4))
+}
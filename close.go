@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Close cancels the release caching running in the background, releases
+// the data streams attached to the release assets and removes the cache
+// directory if it was created automatically (see CleanCache). Files
+// returned by Open have their own streams and must still be closed by the
+// caller. The filesystem remains usable after Close, subsequent calls to
+// Open fetch the asset data again.
+func (rfs *ReleaseFileSystem) Close() error {
+	rfs.stopCacheWarming()
+
+	errs := []error{}
+	for _, a := range rfs.Release.Assets {
+		if err := a.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing %q: %w", a.Name(), err))
+		}
+	}
+
+	if err := rfs.CleanCache(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// CleanCache removes the cache directory when it is a temporary directory
+// created by CacheRelease because no cache path was set. Directories set
+// by the user with WithCachePath are never removed. After cleaning, the
+// filesystem reads the assets from GitHub.
+func (rfs *ReleaseFileSystem) CleanCache() error {
+	if rfs.tempCachePath == "" || rfs.Options.CachePath != rfs.tempCachePath {
+		return nil
+	}
+
+	if err := os.RemoveAll(rfs.tempCachePath); err != nil {
+		return fmt.Errorf("removing temporary cache dir: %w", err)
+	}
+
+	rfs.tempCachePath = ""
+	rfs.Options.CachePath = ""
+	rfs.Options.Cache = false
+	return nil
+}
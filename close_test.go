@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// trackingStream records whether it was closed and fails on close if
+// it has an error set.
+type trackingStream struct {
+	io.Reader
+	closed bool
+	err    error
+}
+
+func (ts *trackingStream) Close() error {
+	ts.closed = true
+	return ts.err
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{
+		"a.txt": []byte("aaa"),
+		"b.txt": []byte("bbb"),
+		"c.txt": []byte("ccc"),
+	})
+
+	streams := map[string]*trackingStream{
+		"a.txt": {Reader: strings.NewReader("aaa")},
+		"b.txt": {Reader: strings.NewReader("bbb"), err: errors.New("close failed")},
+	}
+	for _, a := range rfs.Release.Assets {
+		if s, ok := streams[a.Name()]; ok {
+			a.DataStream = s
+		}
+	}
+
+	err := rfs.Close()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "b.txt")
+	for _, s := range streams {
+		require.True(t, s.closed)
+	}
+	for _, a := range rfs.Release.Assets {
+		require.Nil(t, a.DataStream)
+	}
+
+	// Closing again is a no-op and the files can still be opened
+	require.NoError(t, rfs.Close())
+	data, err := fs.ReadFile(rfs, "a.txt")
+	require.NoError(t, err)
+	require.Equal(t, "aaa", string(data))
+}
+
+func TestCleanCache(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		userPath bool
+	}{
+		{"temporary", false},
+		{"user-provided", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := &ReleaseFileSystem{Options: defaultOptions}
+			if tc.userPath {
+				rfs.Options.CachePath = t.TempDir()
+			}
+			rfs.Release.Assets = []*AssetFile{{
+				FileInfo:   FileInfo{IName: "test.txt", ISize: 4},
+				DataStream: newMemoryStream([]byte("test")),
+			}}
+			rfs.indexAssets()
+			require.NoError(t, rfs.CacheRelease())
+
+			cachePath := rfs.Options.CachePath
+			require.FileExists(t, filepath.Join(cachePath, "test.txt"))
+
+			require.NoError(t, rfs.Close())
+			if tc.userPath {
+				require.DirExists(t, cachePath)
+				require.Equal(t, cachePath, rfs.Options.CachePath)
+				return
+			}
+			require.NoDirExists(t, cachePath)
+			require.Empty(t, rfs.Options.CachePath)
+			require.False(t, rfs.Options.Cache)
+		})
+	}
+}
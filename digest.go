@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// digestAlgorithms maps the algorithm prefixes of GitHub digests to hashes
+var digestAlgorithms = map[string]crypto.Hash{
+	"sha256": crypto.SHA256,
+	"sha384": crypto.SHA384,
+	"sha512": crypto.SHA512,
+}
+
+// parseDigest splits a digest in the <algorithm>:<hex value> form. It
+// returns false if the digest is empty or its algorithm is not supported.
+func parseDigest(digest string) (crypto.Hash, []byte, bool) {
+	algName, value, ok := strings.Cut(digest, ":")
+	if !ok {
+		return 0, nil, false
+	}
+	alg, ok := digestAlgorithms[strings.ToLower(algName)]
+	if !ok || !alg.Available() {
+		return 0, nil, false
+	}
+	sum, err := hex.DecodeString(value)
+	if err != nil || len(sum) != alg.Size() {
+		return 0, nil, false
+	}
+	return alg, sum, true
+}
+
+// verifyCachedDigest checks the data of a cached file against the digest
+// reported by GitHub for the asset. Assets without a usable digest are not
+// checked. Files are only hashed once, later calls for the same path return
+// immediately, and not at all if their digest was recorded when caching them.
+func (rfs *ReleaseFileSystem) verifyCachedDigest(a *AssetFile, path string) error {
+	alg, expected, ok := parseDigest(a.Digest())
+	if !ok {
+		return nil
+	}
+
+	// If the data was hashed when it was cached, compare the recorded
+	// digest instead of reading the file again.
+	if calg, cached, ok := parseDigest(a.CacheDigest); ok && calg == alg {
+		if !bytes.Equal(cached, expected) {
+			return fmt.Errorf("cached %q has digest %x, expected %s: %w", a.Name(), cached, a.Digest(), ErrDigestMismatch)
+		}
+		return nil
+	}
+	if _, done := rfs.verifiedDigests.Load(path); done {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening cached %q: %w", a.Name(), err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	h := alg.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("hashing cached %q: %w", a.Name(), err)
+	}
+
+	if got := h.Sum(nil); !bytes.Equal(got, expected) {
+		return fmt.Errorf("cached %q has digest %x, expected %s: %w", a.Name(), got, a.Digest(), ErrDigestMismatch)
+	}
+
+	rfs.verifiedDigests.Store(path, struct{}{})
+	return nil
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetDigestFields(t *testing.T) {
+	t.Parallel()
+	a := &AssetFile{}
+	require.NoError(t, json.Unmarshal([]byte(
+		`{"id": 1, "node_id": "RA_kwDO", "name": "a.txt", "digest": "sha256:abcd"}`,
+	), a))
+	require.Equal(t, "RA_kwDO", a.NodeID)
+	require.Equal(t, "sha256:abcd", a.Digest())
+}
+
+func TestParseDigest(t *testing.T) {
+	t.Parallel()
+	sum := sha256.Sum256([]byte("data"))
+	for _, tc := range []struct {
+		name   string
+		digest string
+		ok     bool
+	}{
+		{"sha256", fmt.Sprintf("sha256:%x", sum), true},
+		{"empty", "", false},
+		{"unknown-alg", fmt.Sprintf("md5:%x", sum), false},
+		{"bad-hex", "sha256:zz", false},
+		{"wrong-length", "sha256:abcd", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, value, ok := parseDigest(tc.digest)
+			require.Equal(t, tc.ok, ok)
+			if ok {
+				require.Equal(t, sum[:], value)
+			}
+		})
+	}
+}
+
+func TestCachedDigest(t *testing.T) {
+	t.Parallel()
+	data := []byte("release data")
+	good := fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+	bad := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("something else")))
+
+	t.Run("cache-release", func(t *testing.T) {
+		t.Parallel()
+		for _, tc := range []struct {
+			name    string
+			digest  string
+			mustErr bool
+		}{
+			{"match", good, false},
+			{"mismatch", bad, true},
+			{"no-digest", "", false},
+		} {
+			rfs := &ReleaseFileSystem{Options: defaultOptions}
+			rfs.Options.CachePath = t.TempDir()
+			rfs.Release.Assets = []*AssetFile{{
+				FileInfo:   FileInfo{IName: "data.txt", ISize: int64(len(data)), IDigest: tc.digest},
+				DataStream: newMemoryStream(data),
+			}}
+			rfs.indexAssets()
+
+			err := rfs.CacheRelease()
+			if tc.mustErr {
+				require.ErrorIs(t, err, ErrDigestMismatch, tc.name)
+				require.NoFileExists(t, filepath.Join(rfs.Options.CachePath, "data.txt"))
+				continue
+			}
+			require.NoError(t, err, tc.name)
+		}
+	})
+
+	t.Run("open-cached", func(t *testing.T) {
+		t.Parallel()
+		rfs, _ := newRemoteTestFS(t, map[string][]byte{"data.txt": data})
+		rfs.Options.Cache = true
+		rfs.Options.CachePath = t.TempDir()
+		rfs.Release.Assets[0].IDigest = good
+
+		// A corrupted cache file of the right size is a cache miss
+		corrupted := []byte("release DATA")
+		require.NoError(t, os.WriteFile(filepath.Join(rfs.Options.CachePath, "data.txt"), corrupted, 0o600))
+
+		f, err := rfs.OpenCachedFile("data.txt")
+		require.NoError(t, err)
+		require.Equal(t, SourceRemote, f.(*AssetFile).Source())
+		require.NoError(t, f.Close())
+
+		rfs.Options.StrictCache = true
+		_, err = rfs.OpenCachedFile("data.txt")
+		require.ErrorIs(t, err, ErrDigestMismatch)
+	})
+}
+
+func TestCacheDigest(t *testing.T) {
+	t.Parallel()
+	data := []byte("hello")
+	digest := fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+
+	rfs, _ := newRemoteTestFS(t, map[string][]byte{"test.txt": data})
+	rfs.Options.CachePath = t.TempDir()
+	rfs.Release.Assets[0].IDigest = digest
+	require.NoError(t, rfs.CacheRelease())
+	require.Equal(t, digest, rfs.Release.Assets[0].CacheDigest)
+
+	// The digest is persisted in the sidecar and reloaded from it
+	f, err := os.Open(filepath.Join(rfs.Options.CachePath, rfs.cacheMetadataName()))
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+	cached, err := FromReleaseJSON(f)
+	require.NoError(t, err)
+	require.Equal(t, digest, cached.Release.Assets[0].CacheDigest)
+
+	// A recorded digest is checked without reading the file
+	a := &AssetFile{CacheDigest: digest, FileInfo: FileInfo{IName: "x", IDigest: digest}}
+	require.NoError(t, rfs.verifyCachedDigest(a, filepath.Join(t.TempDir(), "missing")))
+	a.CacheDigest = fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("other")))
+	require.ErrorIs(t, rfs.verifyCachedDigest(a, filepath.Join(t.TempDir(), "missing")), ErrDigestMismatch)
+}
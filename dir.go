@@ -4,10 +4,18 @@
 package ghrfs
 
 import (
+	"io"
 	"io/fs"
+	"slices"
+	"strings"
 	"time"
 )
 
+var (
+	_ fs.ReadDirFile = (*ReleaseDir)(nil)
+	_ fs.DirEntry    = (*ReleaseDir)(nil)
+)
+
 // ReleaseDir implements the DirEntry interface abstracting the release as
 // as directory.
 type ReleaseDir struct {
@@ -15,14 +23,17 @@ type ReleaseDir struct {
 	Ctime      time.Time
 	Mtime      time.Time
 	AssetFiles []fs.DirEntry
+	offset     int
 }
 
 func (rd *ReleaseDir) Close() error {
 	return nil
 }
 
+// Read always fails as directories cannot be read, mirroring the
+// os package behavior when reading a directory.
 func (rd *ReleaseDir) Read([]byte) (int, error) {
-	return 0, nil
+	return 0, &fs.PathError{Op: "read", Path: rd.Name(), Err: fs.ErrInvalid}
 }
 
 func (rd *ReleaseDir) Stat() (fs.FileInfo, error) {
@@ -37,19 +48,51 @@ func (*ReleaseDir) IsDir() bool {
 	return true
 }
 
-func Type() fs.FileMode {
+func (*ReleaseDir) Type() fs.FileMode {
 	return fs.ModeDir
 }
 
-func (rd *ReleaseDir) Info() (FileInfo, error) {
+// Info returns the directory information. If the modification time is not
+// set, the creation time is reported instead.
+func (rd *ReleaseDir) Info() (fs.FileInfo, error) {
+	mtime := rd.Mtime
+	if mtime.IsZero() {
+		mtime = rd.Ctime
+	}
 	return FileInfo{
-		IName: rd.Tag,
-		ISize: 0,
-		Ctime: rd.Ctime,
-		Mtime: rd.Mtime,
+		IName:  rd.Tag,
+		ISize:  0,
+		Ctime:  rd.Ctime,
+		Mtime:  mtime,
+		IIsDir: true,
 	}, nil
 }
 
+// ReadDir returns the next n entries of the directory. If n <= 0, all the
+// remaining entries are returned. When n > 0 and there are no more entries
+// left, ReadDir returns io.EOF.
 func (rd *ReleaseDir) ReadDir(n int) ([]fs.DirEntry, error) {
-	return rd.AssetFiles, nil
+	// Entries are returned sorted by name, sort them before the first read
+	if rd.offset == 0 && !slices.IsSortedFunc(rd.AssetFiles, compareEntries) {
+		rd.AssetFiles = slices.SortedFunc(slices.Values(rd.AssetFiles), compareEntries)
+	}
+
+	remaining := rd.AssetFiles[min(rd.offset, len(rd.AssetFiles)):]
+	if n <= 0 {
+		rd.offset = len(rd.AssetFiles)
+		return remaining, nil
+	}
+
+	if len(remaining) == 0 {
+		return []fs.DirEntry{}, io.EOF
+	}
+
+	n = min(n, len(remaining))
+	rd.offset += n
+	return remaining[:n], nil
+}
+
+// compareEntries compares two directory entries by name
+func compareEntries(a, b fs.DirEntry) int {
+	return strings.Compare(a.Name(), b.Name())
 }
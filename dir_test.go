@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io"
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseDirRead(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{"test.txt": []byte("test")})
+	d, err := rfs.Open(".")
+	require.NoError(t, err)
+
+	// ReadAll would never return if Read did not return an error
+	_, err = io.ReadAll(d)
+	require.ErrorIs(t, err, fs.ErrInvalid)
+	var pathErr *fs.PathError
+	require.ErrorAs(t, err, &pathErr)
+	require.Equal(t, "read", pathErr.Op)
+}
+
+func TestReleaseDirStat(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{"test.txt": []byte("test")})
+	d, err := rfs.Open(".")
+	require.NoError(t, err)
+	info, err := d.Stat()
+	require.NoError(t, err)
+	require.True(t, info.IsDir())
+	require.True(t, info.Mode().IsDir())
+}
+
+func TestReleaseDirModTime(t *testing.T) {
+	t.Parallel()
+	created := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	published := time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name   string
+		json   string
+		expect time.Time
+	}{
+		{"published", `{"tag_name": "v1.0.0", "created_at": "2025-01-10T00:00:00Z", "published_at": "2025-01-12T00:00:00Z"}`, published},
+		{"created-only", `{"tag_name": "v1.0.0", "draft": true, "created_at": "2025-01-10T00:00:00Z"}`, created},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs, err := FromReleaseJSON(strings.NewReader(tc.json), WithIncludeDrafts(true))
+			require.NoError(t, err)
+
+			info, err := rfs.Stat(".")
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, info.ModTime())
+
+			d, err := rfs.Open(".")
+			require.NoError(t, err)
+			info, err = d.Stat()
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, info.ModTime())
+		})
+	}
+
+	// Directories built without a modification time report the creation time
+	info, err := (&ReleaseDir{Tag: "v1.0.0", Ctime: created}).Info()
+	require.NoError(t, err)
+	require.Equal(t, created, info.ModTime())
+}
+
+func TestReleaseDirReadDir(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{
+		"test1.txt": []byte("1"), "test2.txt": []byte("2"), "test3.txt": []byte("3"),
+	})
+
+	for _, tc := range []struct {
+		name   string
+		pages  []int
+		expect []int
+	}{
+		{"all", []int{-1}, []int{3}},
+		{"paginated", []int{2, 2}, []int{2, 1}},
+		{"one-by-one", []int{1, 1, 1}, []int{1, 1, 1}},
+		{"then-all", []int{1, 0}, []int{1, 2}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := rfs.Open(".")
+			require.NoError(t, err)
+			d, ok := f.(fs.ReadDirFile)
+			require.True(t, ok)
+
+			for i, n := range tc.pages {
+				entries, err := d.ReadDir(n)
+				require.NoError(t, err)
+				require.Len(t, entries, tc.expect[i])
+			}
+
+			// Once exhausted, paginated reads return EOF
+			entries, err := d.ReadDir(1)
+			require.ErrorIs(t, err, io.EOF)
+			require.Empty(t, entries)
+
+			// ...but reading all returns an empty list
+			entries, err = d.ReadDir(-1)
+			require.NoError(t, err)
+			require.Empty(t, entries)
+		})
+	}
+}
+
+func TestReleaseDirType(t *testing.T) {
+	t.Parallel()
+	var d fs.DirEntry = &ReleaseDir{Tag: "v1.0.0"}
+	require.Equal(t, fs.ModeDir, d.Type())
+	require.True(t, d.Type().IsDir())
+}
+
+func TestReleaseDirReadDirSorted(t *testing.T) {
+	t.Parallel()
+	entries := []fs.DirEntry{
+		&AssetFile{FileInfo: FileInfo{IName: "zeta.txt"}},
+		&AssetFile{FileInfo: FileInfo{IName: "alpha.txt"}},
+		&AssetFile{FileInfo: FileInfo{IName: "mid.txt"}},
+	}
+	d := &ReleaseDir{Tag: "v1.0.0", AssetFiles: entries}
+
+	res, err := d.ReadDir(2)
+	require.NoError(t, err)
+	more, err := d.ReadDir(2)
+	require.NoError(t, err)
+	res = append(res, more...)
+
+	names := []string{}
+	for _, e := range res {
+		names = append(names, e.Name())
+	}
+	require.Equal(t, []string{"alpha.txt", "mid.txt", "zeta.txt"}, names)
+
+	// The caller's slice must not be modified
+	require.Equal(t, "zeta.txt", entries[0].Name())
+}
+
+func TestWalkDirStable(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{
+		"c.txt": []byte("c"), "a.txt": []byte("a"), "b.txt": []byte("b"),
+	})
+	// Shuffle the asset order as the API may return them in any order
+	slices.Reverse(rfs.Release.Assets)
+	rfs.indexAssets()
+
+	walked := []string{}
+	require.NoError(t, fs.WalkDir(rfs, ".", func(path string, _ fs.DirEntry, err error) error {
+		walked = append(walked, path)
+		return err
+	}))
+	require.Equal(t, []string{".", "a.txt", "b.txt", "c.txt"}, walked)
+}
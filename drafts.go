@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"fmt"
+)
+
+// fetchingByTag returns true if the release is looked up by its tag.
+func (rfs *ReleaseFileSystem) fetchingByTag() bool {
+	return rfs.Options.ReleaseID == 0 && !isLatestTag(rfs.Options.Tag)
+}
+
+// fetchDraft looks for a draft release with the configured tag after the
+// tag endpoint returned notFoundErr. If drafts are not included or none is
+// found, notFoundErr is returned with a hint about drafts.
+func (rfs *ReleaseFileSystem) fetchDraft(ctx context.Context, notFoundErr error) (ReleaseData, error) {
+	if !rfs.Options.IncludeDrafts {
+		return ReleaseData{}, fmt.Errorf(
+			"%w (draft releases require authentication and WithIncludeDrafts)", notFoundErr,
+		)
+	}
+
+	releases, err := rfs.listReleases(ctx)
+	if err != nil {
+		return ReleaseData{}, fmt.Errorf("%w (looking for drafts: %w)", notFoundErr, err)
+	}
+
+	for _, r := range releases {
+		if !r.Draft || r.Tag != rfs.Options.Tag {
+			continue
+		}
+		rfs.logger().DebugContext(ctx, "found draft release", "tag", r.Tag, "id", r.ID)
+		return rfs.fetchReleaseData(ctx, fmt.Sprintf(
+			releaseIDURLMask, rfs.Options.Organization, rfs.Options.Repository, r.ID,
+		))
+	}
+	return ReleaseData{}, fmt.Errorf(
+		"%w (no draft found, drafts are only listed to authenticated users with push access)", notFoundErr,
+	)
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/carabiner-dev/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftReleases(t *testing.T) {
+	t.Parallel()
+	const (
+		tagPath  = "repos/example/repo/releases/tags/v2.0.0"
+		listPath = "repos/example/repo/releases?per_page=100&page=1"
+		idPath   = "repos/example/repo/releases/42"
+		idDraft  = `{"id":42,"tag_name":"v2.0.0","draft":true}`
+	)
+	for _, tc := range []struct {
+		name      string
+		opts      Options
+		responses map[string]fakeResponse
+		errIs     error
+		expectID  int64
+	}{
+		{
+			"draft-found",
+			Options{Tag: "v2.0.0", IncludeDrafts: true},
+			map[string]fakeResponse{
+				listPath: {http.StatusOK, `[{"id":41,"tag_name":"v1.0.0"},{"id":42,"tag_name":"v2.0.0","draft":true}]`},
+				idPath:   {http.StatusOK, idDraft},
+			},
+			nil, 42,
+		},
+		{
+			"draft-not-listed",
+			Options{Tag: "v2.0.0", IncludeDrafts: true},
+			map[string]fakeResponse{
+				listPath: {http.StatusOK, `[{"id":41,"tag_name":"v1.0.0"}]`},
+			},
+			ErrReleaseNotFound, 0,
+		},
+		{
+			"drafts-not-included",
+			Options{Tag: "v2.0.0"},
+			map[string]fakeResponse{},
+			ErrReleaseNotFound, 0,
+		},
+		{
+			"draft-by-id-not-included",
+			Options{ReleaseID: 42},
+			map[string]fakeResponse{idPath: {http.StatusOK, idDraft}},
+			ErrDraftRelease, 0,
+		},
+		{
+			"draft-by-id-included",
+			Options{ReleaseID: 42, IncludeDrafts: true},
+			map[string]fakeResponse{idPath: {http.StatusOK, idDraft}},
+			nil, 42,
+		},
+		{
+			"published",
+			Options{Tag: "v2.0.0"},
+			map[string]fakeResponse{tagPath: {http.StatusOK, `{"id":43,"tag_name":"v2.0.0"}`}},
+			nil, 43,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			client, err := github.NewClient(github.WithCaller(&fakeCaller{responses: tc.responses}))
+			require.NoError(t, err)
+			tc.opts.Organization = "example"
+			tc.opts.Repository = "repo"
+			rfs := &ReleaseFileSystem{Options: tc.opts, client: client}
+
+			err = rfs.LoadRelease()
+			if tc.errIs != nil {
+				require.ErrorIs(t, err, tc.errIs)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectID, rfs.Release.ID)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// handleDuplicates checks the release for assets sharing a name. Unless
+// the options ask to deduplicate them, an error is returned listing the
+// colliding assets.
+func (rfs *ReleaseFileSystem) handleDuplicates(data *ReleaseData) error {
+	count := map[string]int{}
+	for _, a := range data.Assets {
+		count[a.Name()]++
+	}
+
+	duplicates := []string{}
+	for name, n := range count {
+		if n > 1 {
+			duplicates = append(duplicates, name)
+		}
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+	slices.Sort(duplicates)
+
+	if !rfs.Options.DeduplicateNames {
+		return fmt.Errorf(
+			"release has duplicate asset names: %s (set WithDeduplicateNames to rename them)",
+			strings.Join(duplicates, ", "),
+		)
+	}
+
+	// Keep the first asset with each name, rename the rest
+	used := map[string]bool{}
+	for _, a := range data.Assets {
+		used[a.Name()] = true
+	}
+	kept := map[string]bool{}
+	for _, a := range data.Assets {
+		name := a.Name()
+		if !kept[name] {
+			kept[name] = true
+			continue
+		}
+		for i := 2; ; i++ {
+			if candidate := suffixName(name, i); !used[candidate] {
+				used[candidate] = true
+				a.IName = candidate
+				break
+			}
+		}
+		rfs.logger().Warn("renamed duplicate asset", "asset", name, "id", a.ID, "name", a.Name())
+	}
+	return nil
+}
+
+// suffixName adds -n to name before its extension. The extension starts
+// at the first dot so compound extensions like .tar.gz are kept whole.
+func suffixName(name string, n int) string {
+	// Skip the leading dot of hidden files
+	start := 0
+	if strings.HasPrefix(name, ".") {
+		start = 1
+	}
+	if i := strings.Index(name[start:], "."); i >= 0 {
+		i += start
+		return fmt.Sprintf("%s-%d%s", name[:i], n, name[i:])
+	}
+	return fmt.Sprintf("%s-%d", name, n)
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/carabiner-dev/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuffixName(t *testing.T) {
+	t.Parallel()
+	for name, expect := range map[string]string{
+		"tool.tar.gz": "tool-2.tar.gz",
+		"tool":        "tool-2",
+		".hidden":     ".hidden-2",
+		".config.yml": ".config-2.yml",
+	} {
+		require.Equal(t, expect, suffixName(name, 2))
+	}
+}
+
+func TestDuplicateNames(t *testing.T) {
+	t.Parallel()
+	const release = `{"tag_name":"v1.0.0","assets":[
+		{"id":1,"name":"tool.tar.gz"},
+		{"id":2,"name":"tool.tar.gz"},
+		{"id":3,"name":"tool-2.tar.gz"},
+		{"id":4,"name":"tool.tar.gz"}
+	]}`
+	for _, tc := range []struct {
+		name    string
+		dedupe  bool
+		mustErr bool
+		expect  map[string]int64
+	}{
+		{"error", false, true, nil},
+		{
+			"dedupe", true, false,
+			map[string]int64{"tool.tar.gz": 1, "tool-3.tar.gz": 2, "tool-2.tar.gz": 3, "tool-4.tar.gz": 4},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			client, err := github.NewClient(github.WithCaller(&fakeCaller{responses: map[string]fakeResponse{
+				"repos/example/repo/releases/tags/v1.0.0": {http.StatusOK, release},
+			}}))
+			require.NoError(t, err)
+			rfs := &ReleaseFileSystem{
+				Options: Options{
+					Organization: "example", Repository: "repo", Tag: "v1.0.0", DeduplicateNames: tc.dedupe,
+				},
+				client: client,
+			}
+
+			err = rfs.LoadRelease()
+			if tc.mustErr {
+				require.ErrorContains(t, err, "tool.tar.gz")
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, rfs.Release.fileIndex, len(tc.expect))
+			for name, id := range tc.expect {
+				info, err := rfs.Stat(name)
+				require.NoError(t, err)
+				require.Equal(t, id, info.(*AssetFile).ID) //nolint:errcheck,forcetypeassert
+			}
+		})
+	}
+}
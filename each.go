@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nozzle/throttler"
+)
+
+// EachAsset opens every asset in the release and calls fn with its path and
+// data, running up to concurrency calls at a time. Assets are opened as with
+// Open, so they are read from the caches when available and verified and
+// decompressed according to the options.
+//
+// When fn or opening an asset fails, the context passed to the remaining
+// work is canceled and no more assets are opened. The errors collected are
+// returned joined.
+func (rfs *ReleaseFileSystem) EachAsset(ctx context.Context, concurrency int, fn func(name string, r io.Reader) error) error {
+	if err := rfs.ensureLoaded(); err != nil {
+		return err
+	}
+
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d", concurrency)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	t := throttler.New(concurrency, len(rfs.Release.Assets))
+	for _, a := range rfs.Release.Assets {
+		go func() {
+			// Skip the remaining assets once the work is canceled and
+			// those hidden from the filesystem
+			if ctx.Err() != nil || !rfs.visible(a.Name()) {
+				t.Done(nil)
+				return
+			}
+
+			if err := rfs.eachAsset(ctx, a.Path(), fn); err != nil {
+				cancel()
+				t.Done(err)
+				return
+			}
+			t.Done(nil)
+		}()
+		t.Throttle()
+	}
+
+	if errs := t.Errs(); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// If nothing failed but the parent context was canceled,
+	// some assets may have been skipped.
+	return context.Cause(ctx)
+}
+
+// eachAsset opens an asset and calls fn with its data.
+func (rfs *ReleaseFileSystem) eachAsset(ctx context.Context, name string, fn func(string, io.Reader) error) error {
+	f, err := rfs.openFile(ctx, name)
+	if err != nil {
+		return fmt.Errorf("opening %q: %w", name, err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	if err := fn(name, f); err != nil {
+		return fmt.Errorf("processing %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEachAsset(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{}
+	for i := range 10 {
+		files[fmt.Sprintf("asset-%d.txt", i)] = fmt.Appendf(nil, "data %d", i)
+	}
+	rfs := newCachedTestFS(t, files)
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+		var mtx sync.Mutex
+		var running, maxRunning atomic.Int32
+		got := map[string][]byte{}
+		err := rfs.EachAsset(t.Context(), 3, func(name string, r io.Reader) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			mtx.Lock()
+			got[name] = data
+			mtx.Unlock()
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, files, got)
+		require.LessOrEqual(t, maxRunning.Load(), int32(3))
+	})
+
+	t.Run("error-cancels", func(t *testing.T) {
+		t.Parallel()
+		errTest := errors.New("test error")
+		var calls atomic.Int32
+		err := rfs.EachAsset(t.Context(), 1, func(string, io.Reader) error {
+			calls.Add(1)
+			return errTest
+		})
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		err := rfs.EachAsset(ctx, 2, func(string, io.Reader) error { return nil })
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("invalid-concurrency", func(t *testing.T) {
+		t.Parallel()
+		require.Error(t, rfs.EachAsset(t.Context(), 0, func(string, io.Reader) error { return nil }))
+	})
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrReleaseNotFound is returned when GitHub responds that the requested
+// release does not exist.
+var ErrReleaseNotFound = errors.New("release not found")
+
+// ErrInvalidOptions is returned when the options are missing required
+// fields or hold values that can't be used to load a release.
+var ErrInvalidOptions = errors.New("invalid options")
+
+// ErrDraftRelease is returned when the release is a draft and the options
+// don't include drafts.
+var ErrDraftRelease = errors.New("release is a draft")
+
+// ErrUnpublishedRelease is returned when the options require a published
+// release and the release is a draft or has no publication date.
+var ErrUnpublishedRelease = errors.New("release is not published")
+
+// ErrSizeMismatch is returned when the length of a download does not
+// match the asset size recorded in the release.
+var ErrSizeMismatch = errors.New("download size does not match the asset size")
+
+// ErrDigestMismatch is returned when the data of an asset does not match
+// the digest reported by GitHub.
+var ErrDigestMismatch = errors.New("asset data does not match its digest")
+
+// ErrCacheMiss is returned when an asset is not in the cache and the
+// options forbid fetching it from the network.
+var ErrCacheMiss = errors.New("asset not found in cache")
+
+// ErrUnsafeName is returned when an asset name cannot be safely used as
+// a file name, for example because it would write outside the cache.
+var ErrUnsafeName = errors.New("unsafe asset name")
+
+// ErrAmbiguousName is returned when a case insensitive lookup or a
+// pattern passed to OpenMatch matches more than one asset.
+var ErrAmbiguousName = errors.New("ambiguous asset name")
+
+// ErrDownloadTooLarge is returned when reading a remote asset exceeds the
+// limit set with WithMaxDownloadSize.
+var ErrDownloadTooLarge = errors.New("download exceeds the maximum size")
+
+// CacheError is returned when the cache directory cannot be written.
+type CacheError struct {
+	Path string
+	Err  error
+}
+
+func (e *CacheError) Error() string {
+	return fmt.Sprintf("cache directory %s is not writable: %v", e.Path, e.Err)
+}
+
+func (e *CacheError) Unwrap() error {
+	return e.Err
+}
+
+// maxErrorBodySize is the maximum number of bytes read from an HTTP error
+// response to include in error messages.
+const maxErrorBodySize = 1024
+
+// errorMessage returns the error message from a failed HTTP response. It
+// reads a bounded prefix of the body and extracts the message from GitHub's
+// JSON error payload, falling back to the raw text or the caller's error
+// when the body was already consumed.
+func errorMessage(resp *http.Response, callErr error) string {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize)) //nolint:errcheck
+	msg := struct {
+		Message string `json:"message"`
+	}{}
+	if err := json.Unmarshal(data, &msg); err == nil && msg.Message != "" {
+		return msg.Message
+	}
+
+	if text := strings.TrimSpace(string(data)); text != "" {
+		return text
+	}
+
+	if callErr != nil {
+		return callErr.Error()
+	}
+	return http.StatusText(resp.StatusCode)
+}
+
+// releaseRef returns a string identifying the configured release for
+// error messages.
+func (rfs *ReleaseFileSystem) releaseRef() string {
+	repo := rfs.Options.Organization + "/" + rfs.Options.Repository
+	switch {
+	case rfs.Options.ReleaseID != 0:
+		return fmt.Sprintf("%s release #%d", repo, rfs.Options.ReleaseID)
+	case isLatestTag(rfs.Options.Tag):
+		return repo + " latest release"
+	default:
+		return repo + "@" + rfs.Options.Tag
+	}
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/carabiner-dev/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseErrors(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		response fakeResponse
+		notFound bool
+		contains []string
+	}{
+		{
+			"not-found", fakeResponse{http.StatusNotFound, `{"message":"Not Found"}`},
+			true, []string{"404", "example/repo@v1.0.0", "Not Found"},
+		},
+		{
+			"server-error", fakeResponse{http.StatusInternalServerError, "upstream exploded"},
+			false, []string{"500", "example/repo@v1.0.0", "upstream exploded"},
+		},
+		{
+			"forbidden", fakeResponse{http.StatusForbidden, `{"message":"API rate limit exceeded"}`},
+			false, []string{"403", "API rate limit exceeded"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			client, err := github.NewClient(github.WithCaller(&fakeCaller{
+				responses: map[string]fakeResponse{"repos/example/repo/releases/tags/v1.0.0": tc.response},
+			}))
+			require.NoError(t, err)
+			rfs := &ReleaseFileSystem{
+				Options: Options{Organization: "example", Repository: "repo", Tag: "v1.0.0"},
+				client:  client,
+			}
+
+			err = rfs.LoadRelease()
+			require.Error(t, err)
+			if tc.notFound {
+				require.ErrorIs(t, err, ErrReleaseNotFound)
+			} else {
+				require.NotErrorIs(t, err, ErrReleaseNotFound)
+			}
+			for _, s := range tc.contains {
+				require.Contains(t, err.Error(), s)
+			}
+		})
+	}
+}
+
+func TestCacheError(t *testing.T) {
+	t.Parallel()
+	// A regular file can't be used as a cache directory
+	notDir := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(notDir, []byte("data"), 0o600))
+
+	for _, path := range []string{notDir, filepath.Join(t.TempDir(), "missing")} {
+		rfs := &ReleaseFileSystem{Options: Options{CachePath: path, ParallelDownloads: 1}}
+		err := rfs.CacheRelease()
+		var cerr *CacheError
+		require.ErrorAs(t, err, &cerr)
+		require.Equal(t, path, cerr.Path)
+		require.Contains(t, err.Error(), path)
+		require.False(t, rfs.Options.Cache)
+	}
+
+	// Writable directories pass and the probe is cleaned up
+	dir := t.TempDir()
+	require.NoError(t, checkWritable(dir))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// assetURLMask is the API endpoint that returns the data of an asset
+	assetURLMask = `repos/%s/%s/releases/assets/%d`
+
+	// storageHostSuffix is the domain of the hosts that serve the asset
+	// data through signed URLs that expire after a while.
+	storageHostSuffix = ".githubusercontent.com"
+)
+
+// errExpiredURL is returned by fetchURL when the storage host rejects the
+// signed download URL because its signature expired.
+var errExpiredURL = errors.New("signed download URL expired")
+
+// expiredSignature returns true if resp is a storage host rejecting a
+// request because the signature of the URL expired. It consumes part of
+// the response body.
+func expiredSignature(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden || resp.Request == nil || resp.Request.URL == nil {
+		return false
+	}
+	if !strings.HasSuffix(resp.Request.URL.Hostname(), storageHostSuffix) {
+		return false
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize)) //nolint:errcheck
+	return bytes.Contains(bytes.ToLower(data), []byte("expired"))
+}
+
+// refreshAsset fetches the current metadata of an asset from the API to
+// get fresh download URLs. It requires a token to authenticate to GitHub.
+func (rfs *ReleaseFileSystem) refreshAsset(ctx context.Context, asset *AssetFile) (*AssetFile, error) {
+	if rfs.token() == "" {
+		return nil, errors.New("refreshing asset URLs requires authentication")
+	}
+	if asset.ID == 0 {
+		return nil, errors.New("asset has no ID")
+	}
+
+	ctx, cancel := withTimeout(ctx, rfs.Options.Timeout)
+	defer cancel()
+	endpoint := fmt.Sprintf(assetURLMask, rfs.Options.Organization, rfs.Options.Repository, asset.ID)
+	resp, err := rfs.client.Call(ctx, http.MethodGet, endpoint, nil)
+	if resp != nil {
+		defer resp.Body.Close() //nolint:errcheck
+	}
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("HTTP error %d refreshing asset: %s", resp.StatusCode, errorMessage(resp, err))
+		}
+		return nil, fmt.Errorf("refreshing asset: %w", err)
+	}
+
+	fresh := &AssetFile{}
+	if err := json.NewDecoder(resp.Body).Decode(fresh); err != nil { //nolint:musttag
+		return nil, fmt.Errorf("decoding asset data: %w", err)
+	}
+	if fresh.URL == "" {
+		return nil, errors.New("no URL found in refreshed asset data")
+	}
+	return fresh, nil
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpiredSignature(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		url    string
+		status int
+		body   string
+		expect bool
+	}{
+		{"expired", "https://objects.githubusercontent.com/x?sig=1", http.StatusForbidden, "<Error>Request has expired</Error>", true},
+		{"other-403", "https://objects.githubusercontent.com/x?sig=1", http.StatusForbidden, "AccessDenied", false},
+		{"not-storage", "https://github.com/x", http.StatusForbidden, "Request has expired", false},
+		{"not-403", "https://objects.githubusercontent.com/x", http.StatusNotFound, "expired", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+			resp := &http.Response{
+				StatusCode: tc.status,
+				Body:       io.NopCloser(strings.NewReader(tc.body)),
+				Request:    &http.Request{URL: u},
+			}
+			require.Equal(t, tc.expect, expiredSignature(resp))
+		})
+	}
+}
+
+func TestRefetchExpired(t *testing.T) {
+	t.Parallel()
+	const (
+		staleURL = "https://objects.githubusercontent.com/asset?sig=stale"
+		freshURL = "https://objects.githubusercontent.com/asset?sig=fresh"
+		endpoint = "repos/example/repo/releases/assets/7"
+	)
+	for _, tc := range []struct {
+		name     string
+		token    string
+		mustErr  bool
+		requests []string
+	}{
+		{"authenticated", "token", false, []string{staleURL, endpoint, freshURL}},
+		{"anonymous", "", true, []string{staleURL}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			caller := &fakeCaller{responses: map[string]fakeResponse{
+				staleURL: {http.StatusForbidden, "<Error><Code>AccessDenied</Code><Message>Request has expired</Message></Error>"},
+				endpoint: {http.StatusOK, `{"id": 7, "name": "asset", "size": 5, "browser_download_url": "` + freshURL + `"}`},
+				freshURL: {http.StatusOK, "hello"},
+			}}
+			rfs := &ReleaseFileSystem{Options: defaultOptions, client: caller, assetCaller: caller}
+			rfs.Options.Organization = "example"
+			rfs.Options.Repository = "repo"
+			rfs.Options.Token = tc.token
+			rfs.Options.Anonymous = tc.token == ""
+			rfs.Release.Assets = []*AssetFile{{ID: 7, URL: staleURL, FileInfo: FileInfo{IName: "asset", ISize: 5}}}
+			rfs.indexAssets()
+
+			data, err := fs.ReadFile(rfs, "asset")
+			require.Equal(t, tc.requests, caller.requests)
+			if tc.mustErr {
+				require.ErrorIs(t, err, errExpiredURL)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "hello", string(data))
+		})
+	}
+}
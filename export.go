@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ArchiveFormat is the format of the archives written by WriteArchive.
+type ArchiveFormat string
+
+const (
+	// ArchiveTar writes an uncompressed tar stream
+	ArchiveTar ArchiveFormat = "tar"
+
+	// ArchiveZip writes a zip file
+	ArchiveZip ArchiveFormat = "zip"
+)
+
+// archiveWriter abstracts the tar and zip writers.
+type archiveWriter interface {
+	// add writes an entry to the archive. The size is -1 if unknown.
+	add(a *AssetFile, size int64, r io.Reader) error
+	Close() error
+}
+
+// WriteArchive writes all the release assets to w as a single archive in
+// the specified format. Entries are named after the assets and carry their
+// size and modification time. Assets are read from the cache if available
+// or downloaded one at a time as they are written to the stream.
+func (rfs *ReleaseFileSystem) WriteArchive(ctx context.Context, w io.Writer, format ArchiveFormat) error {
+	if err := rfs.ensureLoaded(); err != nil {
+		return err
+	}
+
+	var aw archiveWriter
+	switch format {
+	case ArchiveTar:
+		aw = &tarArchiveWriter{Writer: tar.NewWriter(w)}
+	case ArchiveZip:
+		aw = &zipArchiveWriter{Writer: zip.NewWriter(w)}
+	default:
+		return fmt.Errorf("unsupported archive format %q", format)
+	}
+
+	for _, a := range rfs.Release.Assets {
+		if rfs.isCacheSidecar(a.Name()) {
+			continue
+		}
+		if err := rfs.archiveAsset(ctx, aw, a); err != nil {
+			aw.Close() //nolint:errcheck,gosec
+			return fmt.Errorf("archiving %q: %w", a.Name(), err)
+		}
+	}
+
+	return aw.Close()
+}
+
+// archiveAsset writes the data of an asset into the archive.
+func (rfs *ReleaseFileSystem) archiveAsset(ctx context.Context, aw archiveWriter, a *AssetFile) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f, err := rfs.openVerifiedAsset(ctx, a.Name())
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck
+
+	return aw.add(a, f.Size(), f)
+}
+
+// tarArchiveWriter writes the release into a tar stream.
+type tarArchiveWriter struct {
+	*tar.Writer
+}
+
+func (tw *tarArchiveWriter) add(a *AssetFile, size int64, r io.Reader) error {
+	// Tar headers record the size before the data, read the data of
+	// assets of unknown size to find it out.
+	if size < 0 {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		size = int64(len(data))
+		r = newMemoryStream(data)
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     a.Name(),
+		Typeflag: tar.TypeReg,
+		Mode:     0o644,
+		Size:     size,
+		ModTime:  a.ModTime(),
+	}); err != nil {
+		return err
+	}
+
+	n, err := io.Copy(tw, r)
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return errors.New("asset data is shorter than its size")
+	}
+	return nil
+}
+
+// zipArchiveWriter writes the release into a zip file.
+type zipArchiveWriter struct {
+	*zip.Writer
+}
+
+func (zw *zipArchiveWriter) add(a *AssetFile, _ int64, r io.Reader) error {
+	hdr := &zip.FileHeader{
+		Name:     a.Name(),
+		Method:   zip.Deflate,
+		Modified: a.ModTime(),
+	}
+	hdr.SetMode(0o644)
+
+	fw, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, r)
+	return err
+}
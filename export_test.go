@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteArchive(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{
+		"a.txt": []byte("aaa"),
+		"b.bin": bytes.Repeat([]byte{0x01}, 1024),
+	}
+	mtime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	read := map[ArchiveFormat]func(*testing.T, []byte) map[string][]byte{
+		ArchiveTar: func(t *testing.T, data []byte) map[string][]byte {
+			t.Helper()
+			ret := map[string][]byte{}
+			tr := tar.NewReader(bytes.NewReader(data))
+			for {
+				h, err := tr.Next()
+				if errors.Is(err, io.EOF) {
+					return ret
+				}
+				require.NoError(t, err)
+				require.True(t, h.ModTime.Equal(mtime))
+				ret[h.Name], err = io.ReadAll(tr)
+				require.NoError(t, err)
+				require.Equal(t, h.Size, int64(len(ret[h.Name])))
+			}
+		},
+		ArchiveZip: func(t *testing.T, data []byte) map[string][]byte {
+			t.Helper()
+			ret := map[string][]byte{}
+			zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+			require.NoError(t, err)
+			for _, zf := range zr.File {
+				require.True(t, zf.Modified.Equal(mtime))
+				r, err := zf.Open()
+				require.NoError(t, err)
+				ret[zf.Name], err = io.ReadAll(r)
+				require.NoError(t, err)
+				require.NoError(t, r.Close())
+			}
+			return ret
+		},
+	}
+
+	for format, readArchive := range read {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+			rfs := newCachedTestFS(t, files)
+			for _, a := range rfs.Release.Assets {
+				a.Mtime = mtime
+			}
+
+			var buf bytes.Buffer
+			require.NoError(t, rfs.WriteArchive(context.Background(), &buf, format))
+			require.Equal(t, files, readArchive(t, buf.Bytes()))
+		})
+	}
+
+	rfs := newCachedTestFS(t, files)
+	require.Error(t, rfs.WriteArchive(context.Background(), io.Discard, "rar"))
+}
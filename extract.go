@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nozzle/throttler"
+)
+
+// Extract writes the release assets to destDir, creating it if it does not
+// exist. Assets are downloaded `ParallelDownloads` at a time and the
+// CacheMaxSize and CacheExtensions filters in the options are honored.
+//
+// Unlike CacheRelease, Extract does not write the release data sidecar file.
+// If the release is cached, the data is copied from the local cache. Any
+// failed assets are reported in the returned error.
+func (rfs *ReleaseFileSystem) Extract(ctx context.Context, destDir string) error {
+	if err := rfs.ensureLoaded(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return fmt.Errorf("creating destination directory: %w", err)
+	}
+
+	t := throttler.New(rfs.parallelDownloads(len(rfs.Release.Assets)), len(rfs.Release.Assets))
+	for _, a := range rfs.Release.Assets {
+		go func() {
+			if !rfs.cacheable(a) {
+				t.Done(nil)
+				return
+			}
+
+			if err := rfs.extractAsset(ctx, a.Name(), destDir); err != nil {
+				t.Done(fmt.Errorf("extracting %q: %w", a.Name(), err))
+				return
+			}
+			t.Done(nil)
+		}()
+		t.Throttle()
+	}
+
+	return errors.Join(t.Errs()...)
+}
+
+// extractAsset copies the data of a single asset to a file in dir.
+func (rfs *ReleaseFileSystem) extractAsset(ctx context.Context, name, dir string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	path, err := rfs.localPath(dir, name)
+	if err != nil {
+		return err
+	}
+
+	src, err := rfs.openVerifiedAsset(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer src.Close() //nolint:errcheck
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("writing data: %w", err)
+	}
+
+	return dst.Close()
+}
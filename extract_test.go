@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name       string
+		extensions []string
+		expect     []string
+		skip       []string
+	}{
+		{"all", nil, []string{"test1.txt", "test2.json"}, nil},
+		{"filtered", []string{"json"}, []string{"test2.json"}, []string{"test1.txt"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := newCachedTestFS(t, map[string][]byte{
+				"test1.txt":  []byte("test1.txt"),
+				"test2.json": []byte("test2.json"),
+			})
+			rfs.Options.CacheExtensions = tc.extensions
+
+			dest := filepath.Join(t.TempDir(), "out")
+			require.NoError(t, rfs.Extract(t.Context(), dest))
+
+			for _, name := range tc.expect {
+				data, err := os.ReadFile(filepath.Join(dest, name))
+				require.NoError(t, err)
+				require.Equal(t, name, string(data))
+			}
+			for _, name := range tc.skip {
+				require.NoFileExists(t, filepath.Join(dest, name))
+			}
+			require.NoFileExists(t, filepath.Join(dest, releaseDataFile))
+		})
+	}
+}
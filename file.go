@@ -4,13 +4,38 @@
 package ghrfs
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
 	"io"
 	"io/fs"
+	"math"
+	"mime"
+	"os"
+	"path"
 	"sync"
 	"time"
 )
 
-var _ fs.File = (*AssetFile)(nil)
+var (
+	_ fs.File   = (*AssetFile)(nil)
+	_ io.Seeker = (*SeekableAssetFile)(nil)
+)
+
+// Source identifies where the data of an opened file is read from.
+type Source string
+
+const (
+	// SourceRemote files are downloaded from GitHub or a mirror
+	SourceRemote Source = "remote"
+
+	// SourceCache files are read from the local cache directory
+	SourceCache Source = "cache"
+
+	// SourceMemory files are read from the in-memory cache
+	SourceMemory Source = "memory"
+)
 
 // AssetFile abstracts an asset stored in a GitHub release and
 // implements fs.File by reading data from an io.ReadCloser
@@ -18,8 +43,24 @@ type AssetFile struct {
 	DataStream io.ReadCloser
 	mtx        sync.Mutex
 	cachePath  string
+	source     Source
 	URL        string `json:"browser_download_url"`
-	ID         int64  `json:"id"`
+	APIURL     string `json:"url"`
+	Label      string `json:"label,omitempty"`
+	// DisplayPath is the path of the asset in the filesystem when the
+	// name rewriter maps it to a path different from its GitHub name.
+	DisplayPath string `json:"-"`
+	ID          int64  `json:"id"`
+	NodeID      string `json:"node_id,omitempty"`
+
+	// CacheDigest is the sha256 digest of the asset data computed while
+	// writing it to the cache. It is persisted in the release data sidecar.
+	CacheDigest string `json:"cache_digest,omitempty"`
+
+	// ETag and LastModified are the validators returned by the server when
+	// the asset was downloaded. They are used to revalidate cached copies.
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
 	FileInfo
 }
 
@@ -38,6 +79,21 @@ func (af *AssetFile) Close() error {
 	return err
 }
 
+// Source returns where the file data is read from. It is empty for files
+// not returned by Open, such as directory entries.
+func (af *AssetFile) Source() Source {
+	return af.source
+}
+
+// Path returns the path of the asset in the filesystem. It is the asset
+// name unless it was rewritten with WithNameRewriter.
+func (af *AssetFile) Path() string {
+	if af.DisplayPath != "" {
+		return af.DisplayPath
+	}
+	return af.Name()
+}
+
 func (af *AssetFile) Read(p []byte) (int, error) {
 	af.mtx.Lock()
 	defer af.mtx.Unlock()
@@ -50,6 +106,59 @@ func (af *AssetFile) Read(p []byte) (int, error) {
 	return af.DataStream.Read(p)
 }
 
+// decompress replaces the file's data stream with a reader that gunzips it.
+// The file size is set to the uncompressed size when it can be read from the
+// gzip trailer of a local file, or to -1 when it is unknown.
+func (af *AssetFile) decompress() error {
+	af.mtx.Lock()
+	defer af.mtx.Unlock()
+
+	if af.DataStream == nil {
+		return fs.ErrClosed
+	}
+
+	size := int64(-1)
+	if f, ok := af.DataStream.(*os.File); ok {
+		size = gzipSize(f)
+	}
+
+	zr, err := gzip.NewReader(af.DataStream)
+	if err != nil {
+		return err
+	}
+
+	af.DataStream = &gzipStream{Reader: zr, source: af.DataStream}
+	af.ISize = size
+	return nil
+}
+
+// gzipStream reads decompressed data from a gzipped stream. Closing it
+// closes both the gzip reader and the underlying stream.
+type gzipStream struct {
+	*gzip.Reader
+	source io.ReadCloser
+}
+
+func (gs *gzipStream) Close() error {
+	return errors.Join(gs.Reader.Close(), gs.source.Close())
+}
+
+// gzipSize returns the uncompressed size recorded in the trailer of a gzip
+// file or -1 if it cannot be determined. The trailer stores the size modulo
+// 2^32, so files too large to trust it are reported as unknown.
+func gzipSize(f *os.File) int64 {
+	info, err := f.Stat()
+	if err != nil || info.Size() < 18 || info.Size() > math.MaxUint32 {
+		return -1
+	}
+
+	buf := make([]byte, 4)
+	if _, err := f.ReadAt(buf, info.Size()-4); err != nil {
+		return -1
+	}
+	return int64(binary.LittleEndian.Uint32(buf))
+}
+
 func (af *AssetFile) Stat() (fs.FileInfo, error) {
 	return af.FileInfo, nil
 }
@@ -58,8 +167,9 @@ func (af *AssetFile) Info() (fs.FileInfo, error) {
 	return af.FileInfo, nil
 }
 
+// Type returns the type bits of the file mode, as required by fs.DirEntry
 func (af *AssetFile) Type() fs.FileMode {
-	return af.Mode()
+	return af.Mode().Type()
 }
 
 // FileInfo captures the asset information and implements fs.FileInfo
@@ -69,6 +179,18 @@ type FileInfo struct {
 	Ctime  time.Time `json:"created_at"`
 	Mtime  time.Time `json:"updated_at"`
 	IIsDir bool      `json:"isdir"`
+
+	// IContentType is the media type of the asset as reported by GitHub
+	IContentType string `json:"content_type,omitempty"`
+
+	// IDigest is the digest of the asset data computed by GitHub, for
+	// example sha256:1a2b... It is not set in older releases.
+	IDigest string `json:"digest,omitempty"`
+}
+
+// SysInfo is the system data returned by Sys for release assets.
+type SysInfo struct {
+	ContentType string
 }
 
 // Name base name of the file
@@ -99,6 +221,76 @@ func (afd FileInfo) IsDir() bool {
 	return afd.IIsDir
 }
 
+// Digest returns the digest of the file data reported by GitHub in the
+// form <algorithm>:<hex value>, or an empty string if it is not known.
+func (afd FileInfo) Digest() string {
+	return afd.IDigest
+}
+
+// Sys returns a *SysInfo with the asset content type, nil for directories.
 func (afd FileInfo) Sys() any {
+	if afd.IIsDir {
+		return nil
+	}
+	return &SysInfo{ContentType: afd.ContentType()}
+}
+
+// ContentType returns the media type of the file. If GitHub did not report
+// one, it is guessed from the file extension, defaulting to
+// application/octet-stream.
+func (afd FileInfo) ContentType() string {
+	if afd.IContentType != "" {
+		return afd.IContentType
+	}
+	if t := mime.TypeByExtension(path.Ext(afd.IName)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// SeekableAssetFile is an asset file whose data stream supports seeking, as
+// is the case of files read from the disk or memory caches. Open returns
+// seekable files when possible, which makes them usable by http.FileServer
+// to serve range requests.
+type SeekableAssetFile struct {
+	*AssetFile
+}
+
+// Seek sets the offset for the next Read.
+func (sf *SeekableAssetFile) Seek(offset int64, whence int) (int64, error) {
+	sf.mtx.Lock()
+	defer sf.mtx.Unlock()
+
+	if sf.DataStream == nil {
+		return 0, fs.ErrClosed
+	}
+
+	s, ok := sf.DataStream.(io.Seeker)
+	if !ok {
+		return 0, &fs.PathError{Op: "seek", Path: sf.Name(), Err: errors.ErrUnsupported}
+	}
+	return s.Seek(offset, whence)
+}
+
+// seekable returns the file as a SeekableAssetFile if its data stream
+// supports seeking.
+func (af *AssetFile) seekable() fs.File {
+	if _, ok := af.DataStream.(io.Seeker); ok {
+		return &SeekableAssetFile{AssetFile: af}
+	}
+	return af
+}
+
+// memoryStream is a seekable data stream that reads from memory.
+type memoryStream struct {
+	*bytes.Reader
+}
+
+func (memoryStream) Close() error {
 	return nil
 }
+
+// newMemoryStream returns a data stream that reads data.
+func newMemoryStream(data []byte) io.ReadCloser {
+	return memoryStream{Reader: bytes.NewReader(data)}
+}
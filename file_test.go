@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentType(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		info   FileInfo
+		expect string
+	}{
+		{"reported", FileInfo{IName: "tool.tar.gz", IContentType: "application/x-gtar"}, "application/x-gtar"},
+		{"by-extension", FileInfo{IName: "notes.json"}, "application/json"},
+		{"unknown", FileInfo{IName: "tool-linux-amd64"}, "application/octet-stream"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expect, tc.info.ContentType())
+			sys, ok := tc.info.Sys().(*SysInfo)
+			require.True(t, ok)
+			require.Equal(t, tc.expect, sys.ContentType)
+		})
+	}
+
+	// Directories have no system data
+	require.Nil(t, FileInfo{IName: "v1.0.0", IIsDir: true}.Sys())
+
+	// The content type is read from the GitHub API data
+	a := AssetFile{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"sbom.spdx","content_type":"text/spdx"}`), &a))
+	require.Equal(t, "text/spdx", a.ContentType())
+}
+
+func TestFileSource(t *testing.T) {
+	t.Parallel()
+	source := func(t *testing.T, rfs *ReleaseFileSystem, name string) Source {
+		t.Helper()
+		f, err := rfs.Open(name)
+		require.NoError(t, err)
+		defer f.Close() //nolint:errcheck
+		s, ok := f.(interface{ Source() Source })
+		require.True(t, ok)
+		return s.Source()
+	}
+
+	t.Run("cache", func(t *testing.T) {
+		t.Parallel()
+		rfs := newCachedTestFS(t, map[string][]byte{"test.txt": []byte("test")})
+		require.Equal(t, SourceCache, source(t, rfs, "test.txt"))
+	})
+
+	t.Run("remote", func(t *testing.T) {
+		t.Parallel()
+		rfs, _ := newRemoteTestFS(t, map[string][]byte{"test.txt": []byte("test")})
+		require.Equal(t, SourceRemote, source(t, rfs, "test.txt"))
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		t.Parallel()
+		rfs := newCachedTestFS(t, map[string][]byte{"test.txt": []byte("test")})
+		rfs.Options.MemoryCacheSize = 1024
+		require.Equal(t, SourceCache, source(t, rfs, "test.txt"))
+		require.Equal(t, SourceMemory, source(t, rfs, "test.txt"))
+	})
+}
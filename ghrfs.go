@@ -10,27 +10,38 @@
 package ghrfs
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
+	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/carabiner-dev/github"
 	"github.com/nozzle/throttler"
+	"golang.org/x/time/rate"
 )
 
 const (
-	releaseURLMask  = `repos/%s/%s/releases/tags/%s`
-	githubAPIURL    = "api.github.com"
-	releaseDataFile = ".release-data.json"
+	releaseURLMask   = `repos/%s/%s/releases/tags/%s`
+	releaseIDURLMask = `repos/%s/%s/releases/%d`
+	latestURLMask    = `repos/%s/%s/releases/latest`
+	githubAPIURL     = "api.github.com"
+	releaseDataFile  = ".release-data.json"
 )
 
 func New(optFns ...optFunc) (*ReleaseFileSystem, error) {
@@ -44,17 +55,20 @@ func New(optFns ...optFunc) (*ReleaseFileSystem, error) {
 	return NewWithOptions(&opts)
 }
 
-// NewWithOptions takes an options set and return a new RFS
+// NewWithOptions takes an options set and return a new RFS. The options
+// are validated before the release is loaded.
 func NewWithOptions(opts *Options) (*ReleaseFileSystem, error) {
-	c, err := github.NewClient()
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	rfs, err := newReleaseFileSystem(opts)
 	if err != nil {
 		return nil, err
 	}
-	c.Options.Host = opts.Host
 
-	rfs := &ReleaseFileSystem{
-		Options: *opts,
-		client:  c,
+	if opts.LazyLoad {
+		return rfs, nil
 	}
 
 	if err := rfs.LoadRelease(); err != nil {
@@ -64,145 +78,837 @@ func NewWithOptions(opts *Options) (*ReleaseFileSystem, error) {
 	return rfs, nil
 }
 
+// FromReleaseJSON returns a new RFS built from release data previously
+// returned by the GitHub API, read from r. The API is not queried to load
+// the release, assets are still read from their URLs or from the cache.
+func FromReleaseJSON(r io.Reader, optFns ...optFunc) (*ReleaseFileSystem, error) {
+	opts := defaultOptions
+	for _, fn := range optFns {
+		if err := fn(&opts); err != nil {
+			return nil, err
+		}
+	}
+
+	rfs, err := newReleaseFileSystem(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	data := ReleaseData{}
+	if err := json.NewDecoder(r).Decode(&data); err != nil { //nolint:musttag
+		return nil, fmt.Errorf("decoding release data: %w", err)
+	}
+
+	if err := rfs.prepareRelease(&data); err != nil {
+		return nil, err
+	}
+
+	if err := rfs.setRelease(context.Background(), data); err != nil {
+		return nil, fmt.Errorf("loading release: %w", err)
+	}
+
+	return rfs, nil
+}
+
+// newReleaseFileSystem returns an RFS with its API client configured but
+// with no release data loaded.
+func newReleaseFileSystem(opts *Options) (*ReleaseFileSystem, error) {
+	rfs := &ReleaseFileSystem{
+		Options: *opts,
+	}
+
+	c, err := github.NewClient(
+		github.WithHost(opts.Host),
+		github.WithCaller(rfs.newCaller(opts.Host)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	rfs.client = c
+	return rfs, nil
+}
+
 // Ensure RFS implements fs.FS
 var (
-	_ fs.FS        = (*ReleaseFileSystem)(nil)
-	_ fs.StatFS    = (*ReleaseFileSystem)(nil)
-	_ fs.ReadDirFS = (*ReleaseFileSystem)(nil)
+	_ fs.FS         = (*ReleaseFileSystem)(nil)
+	_ fs.StatFS     = (*ReleaseFileSystem)(nil)
+	_ fs.ReadDirFS  = (*ReleaseFileSystem)(nil)
+	_ fs.ReadFileFS = (*ReleaseFileSystem)(nil)
 )
 
+// discardLogger is used when no logger is configured
+var discardLogger = slog.New(slog.DiscardHandler)
+
 // ReleaseFileSystem implements fs.FS by reading data a GitHub release.
 type ReleaseFileSystem struct {
 	Options Options
 	Release ReleaseData
-	client  *github.Client
+	client  apiCaller
+
+	// assetCaller overrides the caller used to download assets
+	assetCaller github.Caller
+
+	memCache     *memoryCache
+	memCacheOnce sync.Once
+
+	httpClientInstance *http.Client
+	httpClientOnce     sync.Once
+
+	// tempCachePath is the cache directory created by CacheRelease when
+	// no cache path was configured.
+	tempCachePath string
+
+	// loadMtx serializes lazy loads, loaded is set once a release is set
+	loadMtx sync.Mutex
+	loaded  atomic.Bool
+
+	// verifiedDigests records the cached files checked against the digest
+	// of their asset
+	verifiedDigests sync.Map
+
+	// verifiedFiles records the cached files accepted by the verifier
+	verifiedFiles sync.Map
+
+	// openSlotsCh limits the number of remote files open at once
+	openSlotsCh   chan struct{}
+	openSlotsOnce sync.Once
+
+	// limiter caps the bandwidth shared by all downloads
+	limiter     *rate.Limiter
+	limiterOnce sync.Once
+
+	// warming tracks the release caching started by WithBackgroundCache
+	warming *cacheWarming
+
+	// archives caches the indexes of the expanded archives
+	archives    map[string]*archiveIndex
+	archivesMtx sync.Mutex
 }
 
 // ReleaseData captures the release information from github
 type ReleaseData struct {
-	ID          int64        `json:"id"`
-	URL         string       `json:"url"`
-	Tag         string       `json:"tag_name"`
-	Draft       bool         `json:"draft"`
-	PublishedAt time.Time    `json:"published_at"`
-	CreatedAt   time.Time    `json:"created_at"`
-	Assets      []*AssetFile `json:"assets"`
+	ID          int64          `json:"id"`
+	URL         string         `json:"url"`
+	HTMLURL     string         `json:"html_url"`
+	Tag         string         `json:"tag_name"`
+	Name        string         `json:"name"`
+	Body        string         `json:"body"`
+	Author      *ReleaseAuthor `json:"author,omitempty"`
+	Draft       bool           `json:"draft"`
+	Prerelease  bool           `json:"prerelease"`
+	PublishedAt time.Time      `json:"published_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	Assets      []*AssetFile   `json:"assets"`
+	TarballURL  string         `json:"tarball_url,omitempty"`
+	ZipballURL  string         `json:"zipball_url,omitempty"`
 	fileIndex   map[string]int
+	pathIndex   map[string]int
+	dirIndex    map[string]struct{}
+	labelIndex  map[string]int
+	foldedIndex map[string][]int
+
+	// warnings records the malformed assets skipped when decoding
+	warnings []error
+}
+
+// modTime returns the time the release was published. Drafts and some
+// releases fetched as latest have no publication date, for those the
+// creation time is returned.
+func (rd *ReleaseData) modTime() time.Time {
+	if rd.PublishedAt.IsZero() {
+		return rd.CreatedAt
+	}
+	return rd.PublishedAt
+}
+
+// ReleaseAuthor captures the GitHub user that created the release
+type ReleaseAuthor struct {
+	ID      int64  `json:"id"`
+	Login   string `json:"login"`
+	HTMLURL string `json:"html_url"`
+}
+
+// ReleaseInfo is the human readable metadata of a release
+type ReleaseInfo struct {
+	ID          int64
+	Tag         string
+	Name        string
+	Body        string
+	HTMLURL     string
+	Author      string
+	Draft       bool
+	Prerelease  bool
+	PublishedAt time.Time
+	CreatedAt   time.Time
+}
+
+// ReleaseInfo returns the metadata of the loaded release, including its
+// title and release notes.
+func (rfs *ReleaseFileSystem) ReleaseInfo() ReleaseInfo {
+	if err := rfs.ensureLoaded(); err != nil {
+		return ReleaseInfo{}
+	}
+
+	info := ReleaseInfo{
+		ID:          rfs.Release.ID,
+		Tag:         rfs.Release.Tag,
+		Name:        rfs.Release.Name,
+		Body:        rfs.Release.Body,
+		HTMLURL:     rfs.Release.HTMLURL,
+		Draft:       rfs.Release.Draft,
+		Prerelease:  rfs.Release.Prerelease,
+		PublishedAt: rfs.Release.PublishedAt,
+		CreatedAt:   rfs.Release.CreatedAt,
+	}
+	if rfs.Release.Author != nil {
+		info.Author = rfs.Release.Author.Login
+	}
+	return info
 }
 
 // LoadRelease queries the GitHub API and loads the release data,
 // optionally catching the assets
 func (rfs *ReleaseFileSystem) LoadRelease() error {
-	// Use the stock release endpoint
-	releaseURL := fmt.Sprintf(
-		releaseURLMask, rfs.Options.Organization, rfs.Options.Repository, rfs.Options.Tag,
-	)
+	ctx, span := rfs.startSpan(context.Background(), "ghrfs.LoadRelease")
+	var data ReleaseData
+	err := rfs.retry(ctx, "load release", func() (err error) {
+		data, err = rfs.fetchRelease(ctx)
+		return err
+	})
+	if err == nil {
+		err = rfs.setRelease(ctx, data)
+	}
+	span.SetAttributes(attrTag.String(rfs.Release.Tag), attrAssetCount.Int(len(rfs.Release.Assets)))
+	endSpan(span, err)
+	return err
+}
+
+// setRelease indexes the release data and makes it the release served by
+// the filesystem, caching it if the options require it.
+func (rfs *ReleaseFileSystem) setRelease(ctx context.Context, data ReleaseData) error {
+	rfs.indexRelease(data)
 
-	// ...unless we're targeting the latest one, which is different:
-	if rfs.Options.Tag == "" || rfs.Options.Tag == "latest" {
-		releaseURL = fmt.Sprintf(
-			"repos/%s/%s/releases/latest", rfs.Options.Organization, rfs.Options.Repository,
+	switch {
+	case rfs.Options.Cache && rfs.Options.BackgroundCache:
+		if err := rfs.startCacheWarming(ctx); err != nil {
+			return fmt.Errorf("caching release: %w", err)
+		}
+	case rfs.Options.Cache:
+		if err := rfs.cacheRelease(ctx); err != nil {
+			return fmt.Errorf("caching release: %w", err)
+		}
+	}
+
+	rfs.loaded.Store(true)
+	return nil
+}
+
+// indexRelease makes data the release served by the filesystem, indexing its
+// assets and logging the problems found when decoding it.
+func (rfs *ReleaseFileSystem) indexRelease(data ReleaseData) {
+	rfs.Release = data
+	rfs.indexAssets()
+
+	for _, w := range data.warnings {
+		rfs.logger().Warn("skipped malformed asset", "release", rfs.releaseRef(), "error", w)
+	}
+
+	if len(rfs.Release.Assets) == 0 {
+		rfs.logger().Warn(
+			"release has no assets", "release", rfs.releaseRef(),
+			"hint", "use WithSourceArchives to list the source code archives",
+		)
+	}
+}
+
+// fetchRelease calls the GitHub API and returns the decoded release data.
+// Draft releases are only returned when the options include them.
+func (rfs *ReleaseFileSystem) fetchRelease(ctx context.Context) (ReleaseData, error) {
+	releaseURL, err := rfs.releasePath(ctx)
+	if err != nil {
+		return ReleaseData{}, err
+	}
+
+	data, err := rfs.fetchReleaseData(ctx, releaseURL)
+	if errors.Is(err, ErrReleaseNotFound) && rfs.fetchingByTag() {
+		// Drafts are not served by the tag endpoint, look for one
+		// in the releases list.
+		data, err = rfs.fetchDraft(ctx, err)
+	}
+	if errors.Is(err, ErrReleaseNotFound) && rfs.fetchingByTag() && rfs.Options.FallbackToTagArchive {
+		// The tag may exist without a release, serve its source code
+		data, err = rfs.fetchTagArchive(ctx, err)
+	}
+	if err != nil {
+		return ReleaseData{}, err
+	}
+
+	if err := rfs.prepareRelease(&data); err != nil {
+		return ReleaseData{}, err
+	}
+	return data, nil
+}
+
+// prepareRelease checks the decoded release data against the options and
+// completes its list of assets before indexing.
+func (rfs *ReleaseFileSystem) prepareRelease(data *ReleaseData) error {
+	if rfs.Options.RequirePublished && (data.Draft || data.PublishedAt.IsZero()) {
+		return fmt.Errorf("%w: %s has not been published", ErrUnpublishedRelease, rfs.releaseRef())
+	}
+
+	if data.Draft && !rfs.Options.IncludeDrafts {
+		return fmt.Errorf(
+			"%w: %s, set WithIncludeDrafts to load it", ErrDraftRelease, rfs.releaseRef(),
 		)
 	}
 
+	if rfs.Options.SourceArchives {
+		rfs.addSourceArchives(data)
+	}
+
+	return rfs.handleDuplicates(data)
+}
+
+// fetchReleaseData fetches the release data from an API endpoint.
+func (rfs *ReleaseFileSystem) fetchReleaseData(ctx context.Context, releaseURL string) (ReleaseData, error) {
 	// Call the API to get the data
-	resp, err := rfs.client.Call(
-		context.Background(), "GET", releaseURL, nil,
-	)
-	if resp.StatusCode > 399 || resp.StatusCode < 200 {
-		return fmt.Errorf("HTTP error %d when getting release data", resp.StatusCode)
+	ctx, cancel := withTimeout(ctx, rfs.Options.Timeout)
+	defer cancel()
+	start := time.Now()
+	rfs.logger().DebugContext(ctx, "fetching release data", "release", rfs.releaseRef(), "endpoint", releaseURL)
+	resp, err := rfs.client.Call(ctx, "GET", releaseURL, nil)
+	if resp != nil {
+		defer resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode > 399 || resp.StatusCode < 200 {
+			herr := fmt.Errorf(
+				"HTTP error %d when getting release data for %s: %s",
+				resp.StatusCode, rfs.releaseRef(), errorMessage(resp, err),
+			)
+			if resp.StatusCode == http.StatusNotFound {
+				return ReleaseData{}, fmt.Errorf("%w: %w", ErrReleaseNotFound, herr)
+			}
+			if transientStatus(resp.StatusCode) {
+				return ReleaseData{}, transientError{herr}
+			}
+			return ReleaseData{}, herr
+		}
 	}
 	if err != nil {
-		return fmt.Errorf("loading release: %w", err)
+		return ReleaseData{}, transientError{fmt.Errorf("loading release %s: %w", rfs.releaseRef(), err)}
+	}
+
+	// The body is only buffered when a tap needs to see it whole, otherwise
+	// it is decoded as it streams.
+	var body io.Reader = resp.Body
+	if rfs.Options.ResponseTap != nil {
+		raw, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return ReleaseData{}, fmt.Errorf("reading release data: %w", err)
+		}
+		rfs.Options.ResponseTap(raw)
+		body = bytes.NewReader(raw)
 	}
-	defer resp.Body.Close() //nolint:errcheck
 
 	data := ReleaseData{}
-	dec := json.NewDecoder(resp.Body)
+	dec := json.NewDecoder(body)
 	if err := dec.Decode(&data); err != nil { //nolint:musttag
-		return fmt.Errorf("unmarshaling release data: %w", err)
+		return ReleaseData{}, fmt.Errorf("unmarshaling release data: %w", err)
+	}
+	rfs.logger().InfoContext(
+		ctx, "resolved release", "release", rfs.releaseRef(), "tag", data.Tag, "id", data.ID,
+		"assets", len(data.Assets), "duration", time.Since(start),
+	)
+	return data, nil
+}
+
+// releasePath returns the API endpoint to fetch the release data. A release
+// ID takes precedence over the tag. An empty tag or "latest" selects the
+// latest release, see isLatestTag.
+func (rfs *ReleaseFileSystem) releasePath(ctx context.Context) (string, error) {
+	if rfs.Options.ReleaseID < 0 {
+		return "", fmt.Errorf("invalid release ID %d", rfs.Options.ReleaseID)
+	}
+
+	if rfs.Options.ReleaseID != 0 {
+		return fmt.Sprintf(
+			releaseIDURLMask, rfs.Options.Organization, rfs.Options.Repository, rfs.Options.ReleaseID,
+		), nil
+	}
+
+	tag := rfs.Options.Tag
+
+	// When resolving latest by semver, find the highest version tag
+	if isLatestTag(tag) && rfs.Options.LatestSemver {
+		var err error
+		tag, err = rfs.resolveLatestSemver(ctx)
+		if err != nil {
+			return "", fmt.Errorf("resolving latest semver release: %w", err)
+		}
+	}
+
+	// The latest endpoint skips prereleases, find the newest one by listing
+	if isLatestTag(tag) && rfs.Options.IncludePrereleases {
+		id, err := rfs.resolveNewest(ctx)
+		if err != nil {
+			return "", fmt.Errorf("resolving newest release: %w", err)
+		}
+		return fmt.Sprintf(
+			releaseIDURLMask, rfs.Options.Organization, rfs.Options.Repository, id,
+		), nil
+	}
+
+	// Targeting the latest release uses a different endpoint
+	if isLatestTag(tag) {
+		return fmt.Sprintf(
+			latestURLMask, rfs.Options.Organization, rfs.Options.Repository,
+		), nil
+	}
+
+	// Use the stock release endpoint
+	return fmt.Sprintf(
+		releaseURLMask, rfs.Options.Organization, rfs.Options.Repository, tag,
+	), nil
+}
+
+// indexAssets drops any assets rejected by the asset filter defined
+// in the options and builds the index of the remaining files.
+func (rfs *ReleaseFileSystem) indexAssets() {
+	if rfs.Options.AssetFilter != nil {
+		rfs.Release.Assets = slices.DeleteFunc(rfs.Release.Assets, func(a *AssetFile) bool {
+			return !rfs.Options.AssetFilter(a)
+		})
 	}
-	rfs.Release = data
 
-	// Index files
+	rfs.archives = nil
 	rfs.Release.fileIndex = map[string]int{}
+	rfs.Release.pathIndex = map[string]int{}
+	rfs.Release.dirIndex = map[string]struct{}{}
+	rfs.Release.foldedIndex = nil
+	if rfs.Options.CaseInsensitiveLookup {
+		rfs.Release.foldedIndex = map[string][]int{}
+	}
 	for i, f := range rfs.Release.Assets {
 		if f.Name() == "" {
 			continue // Not sure if this can happen
 		}
+		if rfs.isCacheSidecar(f.Name()) {
+			continue
+		}
 		rfs.Release.fileIndex[f.Name()] = i
+		if !rfs.visible(f.Name()) {
+			continue
+		}
+
+		// Index the asset under its path in the filesystem, registering
+		// the directories it lives in.
+		f.DisplayPath = rfs.assetPath(f.Name())
+		if !fs.ValidPath(f.Path()) {
+			rfs.logger().Warn("asset name is not a valid path, it is only accessible by name", "asset", f.Name())
+			continue
+		}
+		rfs.Release.pathIndex[f.Path()] = i
+		for dir := path.Dir(f.Path()); dir != "."; dir = path.Dir(dir) {
+			rfs.Release.dirIndex[dir] = struct{}{}
+		}
+
+		if rfs.Release.foldedIndex != nil {
+			folded := strings.ToLower(f.Path())
+			rfs.Release.foldedIndex[folded] = append(rfs.Release.foldedIndex[folded], i)
+		}
 	}
 
-	if rfs.Options.Cache {
-		if err := rfs.CacheRelease(); err != nil {
-			return fmt.Errorf("caching release: %w", err)
+	rfs.indexLabels()
+}
+
+// lookup returns the index of the asset at the path name. If case
+// insensitive lookups are enabled and there is no exact match, the name
+// is matched ignoring case, failing if more than one asset matches.
+func (rfs *ReleaseFileSystem) lookup(name string) (int, error) {
+	if i, ok := rfs.Release.pathIndex[name]; ok {
+		return i, nil
+	}
+
+	if i, ok := rfs.Release.labelIndex[name]; ok {
+		return i, nil
+	}
+
+	if rfs.Release.foldedIndex != nil {
+		matches := rfs.Release.foldedIndex[strings.ToLower(name)]
+		switch len(matches) {
+		case 0:
+		case 1:
+			return matches[0], nil
+		default:
+			names := make([]string, 0, len(matches))
+			for _, i := range matches {
+				names = append(names, rfs.Release.Assets[i].Path())
+			}
+			return 0, fmt.Errorf("%w: %q matches %s", ErrAmbiguousName, name, strings.Join(names, ", "))
 		}
 	}
+	return 0, fs.ErrNotExist
+}
 
-	return nil
+// AssetURL returns the browser download URL of the named asset. The URL can
+// be handed to other systems to download the asset directly from GitHub.
+func (rfs *ReleaseFileSystem) AssetURL(name string) (string, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return "", &fs.PathError{Op: "url", Path: name, Err: err}
+	}
+
+	i, err := rfs.lookup(name)
+	if err != nil {
+		return "", &fs.PathError{Op: "url", Path: name, Err: err}
+	}
+	return rfs.Release.Assets[i].URL, nil
+}
+
+// AssetAPIURL returns the GitHub API URL of the named asset. Requesting it
+// with an "Accept: application/octet-stream" header returns the asset data,
+// which also works for assets in private repositories.
+func (rfs *ReleaseFileSystem) AssetAPIURL(name string) (string, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return "", &fs.PathError{Op: "url", Path: name, Err: err}
+	}
+
+	i, err := rfs.lookup(name)
+	if err != nil {
+		return "", &fs.PathError{Op: "url", Path: name, Err: err}
+	}
+	return rfs.Release.Assets[i].APIURL, nil
+}
+
+// ResolvedTag returns the tag of the loaded release. When the filesystem
+// was created for the "latest" release, it returns the concrete tag that
+// was resolved so it can be recorded for reproducibility. The options are
+// not modified, so Reload keeps following the latest release.
+func (rfs *ReleaseFileSystem) ResolvedTag() string {
+	if err := rfs.ensureLoaded(); err != nil {
+		return ""
+	}
+
+	return rfs.Release.Tag
+}
+
+// AssetCount returns the number of assets in the release.
+func (rfs *ReleaseFileSystem) AssetCount() int {
+	if err := rfs.ensureLoaded(); err != nil {
+		return 0
+	}
+
+	return len(rfs.Release.Assets)
+}
+
+// AssetNames returns the names of the release assets sorted
+// alphabetically. No data is read from the filesystem.
+func (rfs *ReleaseFileSystem) AssetNames() []string {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(rfs.Release.Assets))
+	for _, a := range rfs.Release.Assets {
+		names = append(names, a.Name())
+	}
+	slices.Sort(names)
+	return names
+}
+
+// TotalSize returns the combined size in bytes of all the release assets.
+// Assets of unknown size are not counted.
+func (rfs *ReleaseFileSystem) TotalSize() int64 {
+	if err := rfs.ensureLoaded(); err != nil {
+		return 0
+	}
+
+	var total int64
+	for _, a := range rfs.Release.Assets {
+		if a.Size() > 0 {
+			total += a.Size()
+		}
+	}
+	return total
+}
+
+// LargestAsset returns the largest asset in the release or nil if the
+// release has no assets.
+func (rfs *ReleaseFileSystem) LargestAsset() *AssetFile {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil
+	}
+
+	var largest *AssetFile
+	for _, a := range rfs.Release.Assets {
+		if largest == nil || a.Size() > largest.Size() {
+			largest = a
+		}
+	}
+	return largest
 }
 
 func (rfs *ReleaseFileSystem) Stat(name string) (fs.FileInfo, error) {
-	if name == "." || name == "/" {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+
+	if name == "." {
 		return FileInfo{
 			IName:  rfs.Release.Tag,
 			ISize:  0,
-			Ctime:  rfs.Release.PublishedAt,
-			Mtime:  rfs.Release.PublishedAt,
+			Ctime:  rfs.Release.modTime(),
+			Mtime:  rfs.Release.modTime(),
 			IIsDir: true,
 		}, nil
 	}
-	i, ok := rfs.Release.fileIndex[name]
-	if !ok {
-		return nil, fmt.Errorf("opening %q: %w", name, fs.ErrNotExist)
+	if a, inner, ok := rfs.archiveFor(name); ok {
+		info, err := rfs.statArchive(context.Background(), a, inner)
+		if err != nil {
+			return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+		}
+		return info, nil
+	}
+	if rfs.isDir(name) {
+		return rfs.subdir(name, nil).Info()
+	}
+	if rfs.isMetadataFile(name) {
+		f, err := rfs.metadataFile()
+		if err != nil {
+			return nil, err
+		}
+		return f.FileInfo, nil
+	}
+
+	i, err := rfs.lookup(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
 	}
 
-	return rfs.Release.Assets[i], nil
+	return rfs.assetEntry(rfs.Release.Assets[i]), nil
 }
 
 // ReadDir implements readddir fs
 func (rfs *ReleaseFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
-	// The only "dir" we support is the root, which is the release itself
-	if name != "." && name != "/" {
-		return nil, fs.ErrNotExist
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+
+	if a, inner, ok := rfs.archiveFor(name); ok {
+		entries, err := rfs.archiveDirEntries(context.Background(), a, inner)
+		if err != nil {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+		}
+		return entries, nil
+	}
+
+	// The root is the release itself, other directories only exist
+	// when asset names are rewritten into paths.
+	if name != "." && !rfs.isDir(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
 	}
+	return rfs.dirEntries(name)
+}
+
+// dirEntries returns the entries listed in a directory of the release.
+func (rfs *ReleaseFileSystem) dirEntries(dir string) ([]fs.DirEntry, error) {
 	ret := []fs.DirEntry{}
 	for _, f := range rfs.Release.Assets {
+		if rfs.isMetadataFile(f.Path()) {
+			continue // The virtual file shadows the asset
+		}
+		if rfs.isCacheSidecar(f.Name()) {
+			continue // Never list the cache sidecar as an asset
+		}
+		if !rfs.visible(f.Name()) || path.Dir(f.Path()) != dir {
+			continue
+		}
+		if rfs.isExpanded(f) {
+			ret = append(ret, rfs.subdir(f.Path(), nil))
+			continue
+		}
+		ret = append(ret, rfs.assetEntry(f))
+	}
+
+	for d := range rfs.Release.dirIndex {
+		if path.Dir(d) == dir {
+			ret = append(ret, rfs.subdir(d, nil))
+		}
+	}
+
+	if rfs.Options.ExposeMetadataFile && dir == "." {
+		f, err := rfs.metadataFile()
+		if err != nil {
+			return nil, err
+		}
 		ret = append(ret, f)
 	}
+
+	// fs.ReadDirFS requires the entries sorted by name
+	slices.SortFunc(ret, compareEntries)
 	return ret, nil
 }
 
 // Open opens a file.
 func (rfs *ReleaseFileSystem) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
 	if name == "." {
-		assets := []fs.DirEntry{}
-		for _, f := range rfs.Release.Assets {
-			assets = append(assets, f)
+		assets, err := rfs.dirEntries(name)
+		if err != nil {
+			return nil, err
 		}
 		return &ReleaseDir{
 			Tag:        rfs.Release.Tag,
-			Ctime:      rfs.Release.PublishedAt,
-			Mtime:      rfs.Release.PublishedAt,
+			Ctime:      rfs.Release.modTime(),
+			Mtime:      rfs.Release.modTime(),
 			AssetFiles: assets,
 		}, nil
 	}
 
-	// Validate file exists
-	if _, ok := rfs.Release.fileIndex[name]; !ok {
-		return nil, fmt.Errorf("opening %q: %w", name, fs.ErrNotExist)
+	if a, inner, ok := rfs.archiveFor(name); ok {
+		f, err := rfs.openArchive(context.Background(), a, inner)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		return f, nil
+	}
+
+	if rfs.isDir(name) {
+		assets, err := rfs.dirEntries(name)
+		if err != nil {
+			return nil, err
+		}
+		return rfs.subdir(name, assets), nil
+	}
+
+	if rfs.isMetadataFile(name) {
+		f, err := rfs.metadataFile()
+		if err != nil {
+			return nil, err
+		}
+		return f.seekable(), nil
+	}
+
+	f, err := rfs.openFile(context.Background(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files report the base name of their path in the filesystem
+	f.IName = path.Base(f.Path())
+	return f.seekable(), nil
+}
+
+// ReadFile reads the named file and returns its contents. The buffer is
+// preallocated using the known file size and the file is always closed.
+func (rfs *ReleaseFileSystem) ReadFile(name string) ([]byte, error) {
+	f, err := rfs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+
+	// The size is unknown (-1) for decompressed remote files. Reserve an
+	// extra byte to hit EOF without growing the buffer.
+	size := max(info.Size(), 512-1) + 1
+	data := make([]byte, 0, size)
+	for {
+		n, err := f.Read(data[len(data):cap(data)])
+		data = data[:len(data)+n]
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return data, nil
+			}
+			return nil, err
+		}
+
+		if len(data) >= cap(data) {
+			data = append(data, 0)[:len(data)]
+		}
+	}
+}
+
+// openFile opens an asset for reading using ctx for any requests. The data
+// is verified and decompressed according to the options.
+func (rfs *ReleaseFileSystem) openFile(ctx context.Context, name string) (*AssetFile, error) {
+	// Validate file exists
+	i, err := rfs.lookup(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	name = rfs.Release.Assets[i].Name()
+
+	// Always create a new file handle
+	f, err := rfs.openVerifiedAsset(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if rfs.Options.AutoDecompress && rfs.isCompressed(name) {
+		if err := f.decompress(); err != nil {
+			f.Close() //nolint:errcheck,gosec
+			return nil, fmt.Errorf("decompressing %q: %w", name, err)
+		}
+	}
+	return f, nil
+}
+
+// openAsset opens a new handle to an asset's raw data, reading it from the
+// memory or disk caches when enabled or from GitHub otherwise.
+func (rfs *ReleaseFileSystem) openAsset(ctx context.Context, name string) (*AssetFile, error) {
+	if rfs.memoryCache() != nil {
+		return rfs.openMemoryCachedFile(ctx, name)
 	}
+	return rfs.openStoredFile(ctx, name)
+}
 
-	// Always create a new file handle
-	if rfs.Options.Cache {
-		return rfs.OpenCachedFile(name)
+// openStoredFile opens an asset from the disk cache or from GitHub.
+func (rfs *ReleaseFileSystem) openStoredFile(ctx context.Context, name string) (*AssetFile, error) {
+	// Assets not cached yet by the background caching are read remotely
+	if rfs.Options.Cache && rfs.warming.ready(name) {
+		return rfs.openCachedFile(ctx, name)
 	}
-	return rfs.OpenRemoteFile(name)
+	return rfs.openRemoteFile(ctx, name)
 }
 
 // OpenCachedFile returns an asset file with its data source connected to
 // a local cached file
 func (rfs *ReleaseFileSystem) OpenCachedFile(name string) (fs.File, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	f, err := rfs.openCachedFile(context.Background(), name)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// openCachedFile opens a file from the cache, falling back to fetching
+// it remotely using ctx when it is not found.
+func (rfs *ReleaseFileSystem) openCachedFile(ctx context.Context, name string) (*AssetFile, error) {
 	i, ok := rfs.Release.fileIndex[name]
 	if !ok {
 		return nil, fmt.Errorf("opening %q: %w", name, fs.ErrNotExist)
@@ -211,34 +917,99 @@ func (rfs *ReleaseFileSystem) OpenCachedFile(name string) (fs.File, error) {
 		return nil, fmt.Errorf("unable to open file, release is not cached")
 	}
 
+	if rfs.Options.CacheBackend != nil {
+		return rfs.openBackendFile(ctx, i)
+	}
+
 	if rfs.Options.CachePath == "" {
 		return nil, fmt.Errorf("unable to open file, release cache path not set")
 	}
 
-	cachePath := filepath.Join(rfs.Options.CachePath, name)
+	cachePath, err := rfs.cachedPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", name, err)
+	}
+
+	// Check with the server that the cached copy is still current
+	if rfs.Options.Revalidate {
+		if err := rfs.revalidateCached(ctx, rfs.Release.Assets[i]); err != nil {
+			rfs.logger().DebugContext(ctx, "cache miss", "asset", name, "reason", "revalidation failed", "error", err)
+			return rfs.cacheMiss(ctx, name, "revalidation failed")
+		}
+	}
+
 	f, err := os.Open(cachePath)
 	if err != nil {
 		// If the file was not found, open the remote file
 		if errors.Is(err, os.ErrNotExist) {
-			return rfs.OpenRemoteFile(name)
+			rfs.logger().DebugContext(ctx, "cache miss", "asset", name, "reason", "not cached")
+			return rfs.cacheMiss(ctx, name, "not cached")
 		}
 		return nil, fmt.Errorf("opening cached file: %w", err)
 	}
 
+	// Check the cached file size matches the asset. A mismatch means the
+	// cached copy is truncated or corrupted so we treat it as a cache miss
+	// unless the strict cache option is set.
+	info, err := f.Stat()
+	if err != nil {
+		f.Close() //nolint:errcheck,gosec
+		return nil, fmt.Errorf("checking cached file: %w", err)
+	}
+	if rfs.Release.Assets[i].Size() >= 0 && info.Size() != rfs.Release.Assets[i].Size() {
+		f.Close() //nolint:errcheck,gosec
+		if rfs.Options.StrictCache {
+			return nil, fmt.Errorf(
+				"cached file %q is %d bytes, expected %d", name, info.Size(), rfs.Release.Assets[i].Size(),
+			)
+		}
+		rfs.logger().DebugContext(
+			ctx, "cache miss", "asset", name, "reason", "size mismatch",
+			"size", info.Size(), "expected", rfs.Release.Assets[i].Size(),
+		)
+		return rfs.cacheMiss(ctx, name, "size mismatch")
+	}
+
+	// Check the data against the digest reported by GitHub, if any
+	if err := rfs.verifyCachedDigest(rfs.Release.Assets[i], cachePath); err != nil {
+		f.Close() //nolint:errcheck,gosec
+		if rfs.Options.StrictCache {
+			return nil, err
+		}
+		rfs.logger().DebugContext(ctx, "cache miss", "asset", name, "reason", "digest mismatch", "error", err)
+		return rfs.cacheMiss(ctx, name, "digest mismatch")
+	}
+	rfs.logger().DebugContext(ctx, "cache hit", "asset", name, "size", info.Size())
+
 	// Create a NEW AssetFile instance for each Open() call
 	// This ensures each caller has an independent file handle
 	return &AssetFile{
-		DataStream: f,
-		cachePath:  cachePath,
-		FileInfo:   rfs.Release.Assets[i].FileInfo,
-		URL:        rfs.Release.Assets[i].URL,
-		ID:         rfs.Release.Assets[i].ID,
+		DataStream:   f,
+		cachePath:    cachePath,
+		source:       SourceCache,
+		FileInfo:     rfs.Release.Assets[i].FileInfo,
+		URL:          rfs.Release.Assets[i].URL,
+		APIURL:       rfs.Release.Assets[i].APIURL,
+		DisplayPath:  rfs.Release.Assets[i].DisplayPath,
+		ID:           rfs.Release.Assets[i].ID,
+		NodeID:       rfs.Release.Assets[i].NodeID,
+		ETag:         rfs.Release.Assets[i].ETag,
+		LastModified: rfs.Release.Assets[i].LastModified,
 	}, nil
 }
 
+// cacheMiss handles an asset that can't be read from the cache. The asset
+// is fetched remotely unless the options require failing instead.
+func (rfs *ReleaseFileSystem) cacheMiss(ctx context.Context, name, reason string) (*AssetFile, error) {
+	if rfs.Options.FailOnMissingCache {
+		return nil, fmt.Errorf("%w: %q %s", ErrCacheMiss, name, reason)
+	}
+	return rfs.openRemoteFile(ctx, name)
+}
+
 // getClientForURL returns a github client configured for the hostname
-// of a URL.
-func getClientForURL(urlString string) (*github.Client, error) {
+// of a URL that sends the extra headers in header, if any.
+func (rfs *ReleaseFileSystem) getClientForURL(urlString, accept string, header http.Header) (*github.Client, error) {
 	// The download URL from the assets is not on the same host as
 	// the API, so we need a new client
 	u, err := url.Parse(urlString)
@@ -247,8 +1018,16 @@ func getClientForURL(urlString string) (*github.Client, error) {
 	}
 
 	// Request the file using a client with the asset URL
+	hc := rfs.newCaller(u.Hostname())
+	hc.accept = accept
+	hc.header = header
+	var caller github.Caller = hc
+	if rfs.assetCaller != nil {
+		caller = rfs.assetCaller
+	}
 	c, err := github.NewClient(
 		github.WithHost(u.Hostname()),
+		github.WithCaller(caller),
 	)
 	if err != nil {
 		return nil, err
@@ -258,6 +1037,44 @@ func getClientForURL(urlString string) (*github.Client, error) {
 
 // OpenRemoteFile returns the asset file connected to its data stream
 func (rfs *ReleaseFileSystem) OpenRemoteFile(name string) (fs.File, error) {
+	return rfs.OpenRemoteFileContext(context.Background(), name)
+}
+
+// OpenRemoteFileContext is like OpenRemoteFile but the download is bound
+// to ctx. Once ctx is done, reading the file returns the context error.
+func (rfs *ReleaseFileSystem) OpenRemoteFileContext(ctx context.Context, name string) (fs.File, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	f, err := rfs.openRemoteFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// openRemoteFile fetches an asset from GitHub using ctx for the request.
+func (rfs *ReleaseFileSystem) openRemoteFile(ctx context.Context, name string) (*AssetFile, error) {
+	return rfs.openRemote(ctx, name, nil)
+}
+
+// openRemote fetches an asset, or the range rng of its data when not nil.
+// The span traced for the download ends when the file is closed.
+func (rfs *ReleaseFileSystem) openRemote(ctx context.Context, name string, rng *byteRange) (*AssetFile, error) {
+	ctx, span := rfs.startSpan(ctx, "ghrfs.OpenRemoteFile", attrAsset.String(name))
+	f, err := rfs.fetchRemoteFile(ctx, name, rng)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+	f.DataStream = &spanStream{ReadCloser: f.DataStream, span: span}
+	return f, nil
+}
+
+// fetchRemoteFile opens the data stream of an asset from a mirror or GitHub.
+// When rng is not nil, only that range of the data is requested.
+func (rfs *ReleaseFileSystem) fetchRemoteFile(ctx context.Context, name string, rng *byteRange) (*AssetFile, error) {
 	i, ok := rfs.Release.fileIndex[name]
 	if !ok {
 		return nil, fmt.Errorf("opening %q: %w", name, fs.ErrNotExist)
@@ -270,34 +1087,317 @@ func (rfs *ReleaseFileSystem) OpenRemoteFile(name string) (fs.File, error) {
 		return nil, fmt.Errorf("no URL found in asset data")
 	}
 
-	// Assets are not downloaded from the API, we need a new client
-	c, err := getClientForURL(asset.URL)
+	// Wait for a slot if the number of open remote files is limited. The
+	// slot is held until the file is closed.
+	release, err := rfs.acquireOpen(ctx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("waiting to open %q: %w", name, err)
+	}
+	opened := false
+	defer func() {
+		if !opened {
+			release()
+		}
+	}()
+
+	// If a mirror is configured, try it first and fall back to
+	// the asset URL if it fails.
+	var resp *http.Response
+	header := rng.header()
+	start := time.Now()
+	if rfs.Options.Mirror != "" {
+		rfs.logger().DebugContext(ctx, "fetching asset from mirror", "asset", name, "url", rfs.mirrorURL(asset))
+		resp, err = rfs.fetchURL(ctx, rfs.mirrorURL(asset), "", header)
+		if err == nil && rng == nil {
+			if err = rfs.checkSize(asset, resp); err != nil {
+				resp.Body.Close() //nolint:errcheck,gosec
+				resp = nil
+			}
+		}
+		if err != nil {
+			rfs.logger().InfoContext(ctx, "mirror fetch failed, falling back to GitHub", "asset", name, "error", err)
+		}
 	}
 
-	// Send the request to the API
-	resp, err := c.Call(
-		context.Background(), "GET",
-		asset.URL, nil,
+	if resp == nil {
+		downloadURL, accept := rfs.downloadURL(asset)
+		rfs.logger().DebugContext(ctx, "fetching asset", "asset", name, "url", downloadURL)
+		err = rfs.retry(ctx, "download "+name, func() (err error) {
+			resp, err = rfs.fetchURL(ctx, downloadURL, accept, header)
+			return err
+		})
+		if errors.Is(err, errExpiredURL) && rfs.token() != "" {
+			resp, err = rfs.refetchExpired(ctx, asset, header)
+		}
+		if err != nil {
+			rfs.logger().DebugContext(ctx, "fetching asset failed", "asset", name, "error", err)
+			return nil, fmt.Errorf("requesting asset %q: %w", name, err)
+		}
+		if rng == nil {
+			if err := rfs.checkSize(asset, resp); err != nil {
+				resp.Body.Close() //nolint:errcheck,gosec
+				return nil, err
+			}
+		}
+	}
+	rfs.logger().DebugContext(
+		ctx, "fetched asset", "asset", name, "size", resp.ContentLength, "duration", time.Since(start),
 	)
-	if err != nil {
-		return nil, fmt.Errorf("requesting asset %q: %w", name, err)
+
+	// Check the data read matches the expected length. Prefer the length
+	// reported by the server, falling back to the recorded asset size if
+	// it is known and the whole asset was requested.
+	expected := resp.ContentLength
+	if expected < 0 && rng == nil {
+		expected = asset.Size()
+	}
+
+	var stream io.ReadCloser = resp.Body
+	if expected >= 0 {
+		stream = &lengthChecker{ReadCloser: resp.Body, name: name, expected: expected}
+	}
+
+	info := asset.FileInfo
+	if rng != nil {
+		if stream, err = rng.apply(stream, resp.StatusCode); err != nil {
+			return nil, fmt.Errorf("reading range of %q: %w", name, err)
+		}
+		if rng.length > 0 {
+			info.ISize = rng.length
+		}
+	}
+
+	// Guard against pulling more data than allowed unless the recorded
+	// size of the asset is known to be within the limit.
+	if limit := rfs.Options.MaxDownloadSize; limit > 0 && (asset.Size() <= 0 || asset.Size() > limit) {
+		stream = &maxSizeReader{ReadCloser: stream, name: name, max: limit}
 	}
 
-	if resp.StatusCode > 399 || resp.StatusCode < 200 {
-		resp.Body.Close() //nolint:errcheck,gosec
-		return nil, fmt.Errorf("HTTP error %d when getting asset %q", resp.StatusCode, name)
+	stream = rfs.throttle(ctx, stream)
+
+	// Stop reading as soon as the caller's context is done
+	if ctx.Done() != nil {
+		stream = &contextReader{ReadCloser: stream, ctx: ctx}
 	}
+	stream = &releaseOnClose{ReadCloser: stream, release: release}
+	opened = true
 
 	// Create a NEW AssetFile instance for each Open() call
-	return &AssetFile{
-		DataStream: resp.Body,
-		cachePath:  "", // No cache path for remote files
-		FileInfo:   asset.FileInfo,
-		URL:        asset.URL,
-		ID:         asset.ID,
-	}, nil
+	f := &AssetFile{
+		DataStream:  stream,
+		cachePath:   "", // No cache path for remote files
+		source:      SourceRemote,
+		FileInfo:    info,
+		URL:         asset.URL,
+		APIURL:      asset.APIURL,
+		DisplayPath: asset.DisplayPath,
+		ID:          asset.ID,
+		NodeID:      asset.NodeID,
+	}
+
+	// Validators only describe the data when all of it was downloaded
+	if rng == nil {
+		f.ETag, f.LastModified = resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	}
+	return f, nil
+}
+
+// refetchExpired retries a download rejected because its signed URL
+// expired. The asset metadata is fetched again to get fresh download URLs
+// and the download is retried once.
+func (rfs *ReleaseFileSystem) refetchExpired(
+	ctx context.Context, asset *AssetFile, header http.Header,
+) (*http.Response, error) {
+	rfs.logger().InfoContext(ctx, "download URL expired, refreshing asset data", "asset", asset.Name())
+	fresh, err := rfs.refreshAsset(ctx, asset)
+	if err != nil {
+		return nil, fmt.Errorf("%w, refreshing it failed: %w", errExpiredURL, err)
+	}
+	downloadURL, accept := rfs.downloadURL(fresh)
+	return rfs.fetchURL(ctx, downloadURL, accept, header)
+}
+
+// checkSize compares the length of a download with the size of the asset
+// recorded in the release when both are known and the strict size option
+// is enabled.
+func (rfs *ReleaseFileSystem) checkSize(asset *AssetFile, resp *http.Response) error {
+	if !rfs.Options.StrictSize || resp.ContentLength < 0 || asset.Size() <= 0 {
+		return nil
+	}
+	if resp.ContentLength != asset.Size() {
+		return fmt.Errorf(
+			"download of %q is %d bytes but the release lists %d: %w",
+			asset.Name(), resp.ContentLength, asset.Size(), ErrSizeMismatch,
+		)
+	}
+	return nil
+}
+
+// fetchURL requests a URL, sending the extra headers in header, and returns
+// the response when successful. The request is bounded by the download
+// timeout, which also covers reading the response body.
+func (rfs *ReleaseFileSystem) fetchURL(
+	ctx context.Context, urlString, accept string, header http.Header,
+) (*http.Response, error) {
+	// Assets are not downloaded from the API, we need a new client
+	c, err := rfs.getClientForURL(urlString, accept, header)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withTimeout(ctx, rfs.Options.DownloadTimeout)
+	resp, err := c.Call(ctx, "GET", urlString, nil)
+	if err != nil || resp.StatusCode > 399 || resp.StatusCode < 200 {
+		defer cancel()
+		if resp == nil {
+			return nil, transientError{err}
+		}
+		defer resp.Body.Close() //nolint:errcheck
+
+		if expiredSignature(resp) {
+			return nil, fmt.Errorf("%w when getting %s", errExpiredURL, urlString)
+		}
+		if transientStatus(resp.StatusCode) {
+			return nil, transientError{fmt.Errorf("HTTP error %d when getting %s", resp.StatusCode, urlString)}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("HTTP error %d when getting %s", resp.StatusCode, urlString)
+	}
+
+	// Release the context once the caller is done with the body
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// parallelDownloads returns the number of workers used to download jobs
+// assets. When ParallelDownloads is not positive there is no limit.
+func (rfs *ReleaseFileSystem) parallelDownloads(jobs int) int {
+	if rfs.Options.ParallelDownloads > 0 {
+		return rfs.Options.ParallelDownloads
+	}
+	return max(jobs, 1)
+}
+
+// withTimeout returns a context bounded by timeout. If timeout is not
+// positive, the parent context is returned with a no-op cancel function.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// cancelOnClose cancels a context when the stream is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (cc *cancelOnClose) Close() error {
+	defer cc.cancel()
+	return cc.ReadCloser.Close()
+}
+
+// contextReader fails reads once its context is done, even if the
+// underlying stream has buffered data left.
+type contextReader struct {
+	io.ReadCloser
+	ctx context.Context
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.ReadCloser.Read(p)
+}
+
+// lengthChecker verifies that the stream returns exactly the expected number
+// of bytes. If the stream ends early or returns extra data, the final read
+// returns an error wrapping io.ErrUnexpectedEOF instead of io.EOF.
+type lengthChecker struct {
+	io.ReadCloser
+	name     string
+	expected int64
+	read     int64
+}
+
+func (lc *lengthChecker) Read(p []byte) (int, error) {
+	n, err := lc.ReadCloser.Read(p)
+	lc.read += int64(n)
+	if errors.Is(err, io.EOF) && lc.read != lc.expected {
+		return n, fmt.Errorf(
+			"reading %q: got %d bytes, expected %d: %w",
+			lc.name, lc.read, lc.expected, io.ErrUnexpectedEOF,
+		)
+	}
+	return n, err
+}
+
+// maxSizeReader returns an error wrapping ErrDownloadTooLarge once the
+// stream returns more than max bytes.
+type maxSizeReader struct {
+	io.ReadCloser
+	name     string
+	max      int64
+	read     int64
+	exceeded bool
+}
+
+func (mr *maxSizeReader) Read(p []byte) (int, error) {
+	if mr.exceeded {
+		return 0, mr.err()
+	}
+
+	// Read at most one byte past the limit to detect an oversized stream
+	remaining := mr.max - mr.read
+	if int64(len(p)) > remaining+1 {
+		p = p[:remaining+1]
+	}
+	n, err := mr.ReadCloser.Read(p)
+	if int64(n) > remaining {
+		mr.exceeded = true
+		mr.read = mr.max
+		return int(remaining), mr.err()
+	}
+	mr.read += int64(n)
+	return n, err
+}
+
+func (mr *maxSizeReader) err() error {
+	return fmt.Errorf("reading %q: %w (limit is %d bytes)", mr.name, ErrDownloadTooLarge, mr.max)
+}
+
+// downloadURL returns the URL to download an asset from GitHub and the
+// media type to request, if any. When downloading via the API, the asset
+// API URL is used requesting its raw data. The URL is passed through the
+// URL rewriter when one is set.
+func (rfs *ReleaseFileSystem) downloadURL(asset *AssetFile) (urlString, accept string) {
+	urlString = asset.URL
+	if rfs.Options.DownloadVia == DownloadViaAPI && asset.APIURL != "" {
+		urlString, accept = asset.APIURL, "application/octet-stream"
+	}
+
+	if rfs.Options.URLRewriter != nil {
+		urlString = rfs.Options.URLRewriter(urlString)
+	}
+	return urlString, accept
+}
+
+// mirrorURL returns the URL of an asset in the configured mirror.
+func (rfs *ReleaseFileSystem) mirrorURL(asset *AssetFile) string {
+	tag := rfs.Release.Tag
+	if tag == "" {
+		tag = rfs.Options.Tag
+	}
+	return strings.NewReplacer(
+		"{org}", rfs.Options.Organization,
+		"{repo}", rfs.Options.Repository,
+		"{tag}", tag,
+		"{name}", asset.Name(),
+	).Replace(rfs.Options.Mirror)
 }
 
 // CacheRelease downloads `ParallelDownloads` assets at a time and caches them
@@ -305,78 +1405,325 @@ func (rfs *ReleaseFileSystem) OpenRemoteFile(name string) (fs.File, error) {
 // file. If assets already have a DataStream defined, it is reused for copying
 // and it will be closed to be replaced by the new local file when it is used.
 func (rfs *ReleaseFileSystem) CacheRelease() error {
-	// If there is no cache path specified, create a temporary file
+	if err := rfs.ensureLoaded(); err != nil {
+		return err
+	}
+
+	return rfs.cacheRelease(context.Background())
+}
+
+// cacheRelease caches the release using ctx for the downloads.
+func (rfs *ReleaseFileSystem) cacheRelease(ctx context.Context) error {
+	if rfs.Options.CacheBackend == nil {
+		if err := rfs.prepareCacheDir(); err != nil {
+			return err
+		}
+	}
+
+	// Refuse to overwrite an asset with the release data sidecar
+	if slices.ContainsFunc(rfs.Release.Assets, func(a *AssetFile) bool {
+		return a.Name() == rfs.cacheMetadataName()
+	}) {
+		return fmt.Errorf(
+			"asset %q collides with the cache metadata file, set a different name with WithCacheMetadataName",
+			rfs.cacheMetadataName(),
+		)
+	}
+
+	// All the assets explicitly listed to cache must exist
+	for _, name := range rfs.Options.CacheAssets {
+		if _, ok := rfs.Release.fileIndex[name]; !ok {
+			return fmt.Errorf("asset %q listed to cache not found in release", name)
+		}
+	}
+
+	// Cache the release data into a JSON file
+	if err := rfs.writeReleaseData(); err != nil {
+		return err
+	}
+
+	// Now copy the file data to the local cache
+	err := rfs.cacheAssets(ctx, rfs.Release.Assets)
+	if !rfs.Options.Cache {
+		rfs.Options.Cache = true
+	}
+
+	return err
+}
+
+// prepareCacheDir makes sure the cache directory exists and is writable,
+// creating a temporary one if there is no cache path specified.
+func (rfs *ReleaseFileSystem) prepareCacheDir() error {
 	if rfs.Options.CachePath == "" {
 		path, err := os.MkdirTemp("", "github-release-fs-")
 		if err != nil {
 			return fmt.Errorf("creating temporary cache dir: %w", err)
 		}
 		rfs.Options.CachePath = path
+		rfs.tempCachePath = path
 	}
 
-	// Cache the release data into a JSON file
-	f, err := os.Create(filepath.Join(rfs.Options.CachePath, releaseDataFile))
+	// Make sure we can write to the cache before downloading anything.
+	// The per tag directories of nested layouts are created as needed.
+	if rfs.cacheDir() != rfs.Options.CachePath {
+		if err := os.MkdirAll(rfs.cacheDir(), 0o755); err != nil {
+			return &CacheError{Path: rfs.cacheDir(), Err: err}
+		}
+	}
+	return checkWritable(rfs.cacheDir())
+}
+
+// checkWritable verifies that files can be created in dir by writing and
+// removing a probe file. It returns a *CacheError if it fails.
+func checkWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".ghrfs-probe-*")
+	if err != nil {
+		return &CacheError{Path: dir, Err: err}
+	}
+	f.Close() //nolint:errcheck,gosec
+	if err := os.Remove(f.Name()); err != nil {
+		return &CacheError{Path: dir, Err: err}
+	}
+	return nil
+}
+
+// cacheDir returns the directory where the release files are cached,
+// according to the cache layout.
+func (rfs *ReleaseFileSystem) cacheDir() string {
+	if rfs.Options.CacheLayout != CacheLayoutByTag {
+		return rfs.Options.CachePath
+	}
+
+	tag := rfs.Release.Tag
+	if tag == "" {
+		tag = rfs.Options.Tag
+	}
+	return filepath.Join(rfs.Options.CachePath, rfs.Options.Organization, rfs.Options.Repository, tag)
+}
+
+// cacheMetadataName returns the name of the release data sidecar file.
+func (rfs *ReleaseFileSystem) cacheMetadataName() string {
+	if rfs.Options.CacheMetadataName == "" {
+		return releaseDataFile
+	}
+	return rfs.Options.CacheMetadataName
+}
+
+// isCacheSidecar returns true if name is reserved for the release data
+// sidecar in the cache directory. The sidecar is never indexed or listed
+// as an asset when the release is cached.
+func (rfs *ReleaseFileSystem) isCacheSidecar(name string) bool {
+	return rfs.Options.Cache && name == rfs.cacheMetadataName()
+}
+
+// writeReleaseData writes the release data sidecar file to the cache.
+func (rfs *ReleaseFileSystem) writeReleaseData() error {
+	if rfs.Options.CacheBackend != nil {
+		return rfs.putReleaseData()
+	}
+
+	f, err := os.Create(filepath.Join(rfs.cacheDir(), rfs.cacheMetadataName()))
 	if err != nil {
 		return fmt.Errorf("creating release data file: %w", err)
 	}
 
 	//nolint:musttag
 	if err := json.NewEncoder(f).Encode(rfs.Release); err != nil {
+		f.Close() //nolint:errcheck,gosec
 		return fmt.Errorf("encoding release data: %w", err)
 	}
 
-	// Now copy the file data to the local cache
-	t := throttler.New((rfs.Options.ParallelDownloads), len(rfs.Release.Assets))
-	for _, a := range rfs.Release.Assets {
+	// Flush the data to disk before closing to make sure the sidecar
+	// is complete when read back.
+	if err := f.Sync(); err != nil {
+		f.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("syncing release data file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing release data file: %w", err)
+	}
+	return nil
+}
+
+// cacheAssets copies the data of the specified assets to the cache
+// directory, downloading `ParallelDownloads` assets at a time. Any errors
+// from the download goroutines are returned joined.
+func (rfs *ReleaseFileSystem) cacheAssets(ctx context.Context, assets []*AssetFile) error {
+	t := throttler.New(rfs.parallelDownloads(len(assets)), len(assets))
+	for _, a := range assets {
 		go func() {
 			// Check if the options have preferences for max size or extensions
 			// to cache. If unmatched, the asset will not be cached but it will
 			// be pulled remotely if needed.
-
-			// Skip if over max size
-			if rfs.Options.CacheMaxSize > 0 && rfs.Options.CacheMaxSize < a.Size() {
+			if !rfs.cacheable(a) {
+				rfs.logger().DebugContext(ctx, "skipping asset caching", "asset", a.Name(), "size", a.Size())
 				t.Done(nil)
 				return
 			}
 
-			// Skip if extensions are defined but the file ext is not one of them
-			if len(rfs.Options.CacheExtensions) > 0 &&
-				(strings.TrimPrefix(filepath.Ext(a.Name()), ".") == "" ||
-					!slices.Contains(rfs.Options.CacheExtensions, strings.TrimPrefix(filepath.Ext(a.Name()), "."))) {
-				t.Done(nil)
-				return
-			}
+			// Bound the whole asset download so a hung connection fails
+			// instead of holding its slot in the throttler.
+			actx, cancel := withTimeout(ctx, rfs.Options.DownloadTimeout)
+			defer cancel()
 
-			var src fs.File
-			var err error
-			if a.DataStream != nil {
-				src = a
-			} else {
-				src, err = rfs.OpenRemoteFile(a.Name())
-				if err != nil {
-					t.Done(err)
-					return
-				}
+			start := time.Now()
+			actx, span := rfs.startSpan(actx, "ghrfs.CacheAsset", attrAsset.String(a.Name()))
+			err := rfs.cacheAsset(actx, a)
+			if err == nil {
+				span.SetAttributes(attrBytes.Int64(a.Size()))
 			}
-
-			dst, err := os.Create(filepath.Join(rfs.Options.CachePath, a.Name()))
+			endSpan(span, err)
 			if err != nil {
+				rfs.logger().WarnContext(ctx, "caching asset failed", "asset", a.Name(), "error", err)
 				t.Done(err)
 				return
 			}
-
-			if _, err := io.Copy(dst, src); err != nil {
-				t.Done(err)
-				return
-			}
-			// Close the source file handle we opened
-			src.Close() //nolint:errcheck,gosec
-
+			rfs.logger().InfoContext(
+				ctx, "cached asset", "asset", a.Name(), "size", a.Size(), "duration", time.Since(start),
+			)
+			rfs.warming.markCached(a.Name())
 			t.Done(nil)
 		}()
 		t.Throttle()
 	}
-	rfs.Options.Cache = true
 
+	// Rewrite the release data to record the digests of the cached files
+	errs := t.Errs()
+	if len(assets) > 0 {
+		if err := rfs.writeReleaseData(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Return any errors collected from the download goroutines
+	return errors.Join(errs...)
+}
+
+// cacheAsset copies the data of an asset to the cache directory.
+func (rfs *ReleaseFileSystem) cacheAsset(ctx context.Context, a *AssetFile) error {
+	if rfs.Options.CacheBackend != nil {
+		return rfs.cacheBackendAsset(ctx, a)
+	}
+
+	path, err := rfs.cachedPath(a.Name())
+	if err != nil {
+		return fmt.Errorf("caching %q: %w", a.Name(), err)
+	}
+	rfs.verifiedFiles.Delete(path)
+
+	// Continue partial downloads left by an interrupted run. If resuming
+	// fails, the partial file is dropped and the whole asset downloaded.
+	if rfs.Options.ResumableCache && a.DataStream == nil {
+		if info, err := os.Stat(path); err == nil && info.Size() > 0 && info.Size() <= a.Size() {
+			err := rfs.resumeCachedFile(ctx, a, path, info.Size())
+			if err == nil {
+				return nil
+			}
+			rfs.logger().WarnContext(ctx, "resuming download failed, downloading the whole asset", "asset", a.Name(), "error", err)
+			os.Remove(path) //nolint:errcheck,gosec
+		}
+	}
+
+	var src fs.File
+	if a.DataStream != nil {
+		src = a
+	} else {
+		src, err = rfs.openRemoteFile(ctx, a.Name())
+		if err != nil {
+			return fmt.Errorf("caching %q: %w", a.Name(), err)
+		}
+	}
+	// Close the source file handle we opened
+	defer src.Close() //nolint:errcheck
+	recordValidators(a, src)
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("caching %q: %w", a.Name(), err)
+	}
+
+	// Hash the data as it is copied to record its digest without
+	// reading the cached file again.
+	a.CacheDigest = ""
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(dst, h), src); err != nil {
+		dst.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("caching %q: %w", a.Name(), err)
+	}
+
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("caching %q: %w", a.Name(), err)
+	}
+	a.CacheDigest = "sha256:" + hex.EncodeToString(h.Sum(nil))
+
+	if err := rfs.setCachedTimes(a, path); err != nil {
+		return err
+	}
+
+	return rfs.verifyCachedFile(ctx, a)
+}
+
+// setCachedTimes sets the access and modification times of the cached file
+// at path to the asset modification time when the options ask to preserve them.
+func (rfs *ReleaseFileSystem) setCachedTimes(a *AssetFile, path string) error {
+	if !rfs.Options.PreserveTimestamps || a.ModTime().IsZero() {
+		return nil
+	}
+	if err := os.Chtimes(path, a.ModTime(), a.ModTime()); err != nil {
+		return fmt.Errorf("setting times of cached %q: %w", a.Name(), err)
+	}
 	return nil
 }
+
+// cacheable returns true if the asset passes the cache filters defined in
+// the options (CacheAssets, CacheMaxSize and CacheExtensions).
+func (rfs *ReleaseFileSystem) cacheable(a *AssetFile) bool {
+	ok, _ := rfs.cacheDecision(a)
+	return ok
+}
+
+// cacheDecision returns true if the asset should be cached. When it is
+// skipped, it also returns the reason.
+func (rfs *ReleaseFileSystem) cacheDecision(a *AssetFile) (bool, string) {
+	// An explicit list of assets takes precedence over the other filters
+	if len(rfs.Options.CacheAssets) > 0 {
+		if slices.Contains(rfs.Options.CacheAssets, a.Name()) {
+			return true, ""
+		}
+		return false, "asset is not in the list of assets to cache"
+	}
+
+	// Skip if over max size
+	if rfs.Options.CacheMaxSize > 0 && rfs.Options.CacheMaxSize < a.Size() {
+		return false, fmt.Sprintf("size %d exceeds the cache maximum of %d bytes", a.Size(), rfs.Options.CacheMaxSize)
+	}
+
+	// Skip if extensions are defined but the file ext is not one of them
+	ext := strings.TrimPrefix(filepath.Ext(a.Name()), ".")
+	if len(rfs.Options.CacheExtensions) > 0 &&
+		(ext == "" || !slices.ContainsFunc(rfs.Options.CacheExtensions, func(e string) bool {
+			return strings.EqualFold(e, ext)
+		})) {
+		return false, fmt.Sprintf("extension %q is not in the cache extensions list", ext)
+	}
+	return true, ""
+}
+
+// isCompressed returns true if the asset name ends with one of the
+// compressed file suffixes defined in the options.
+func (rfs *ReleaseFileSystem) isCompressed(name string) bool {
+	for _, suffix := range rfs.Options.CompressedSuffixes {
+		if suffix != "" && strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// logger returns the configured logger, discarding logs if none is set.
+func (rfs *ReleaseFileSystem) logger() *slog.Logger {
+	if rfs.Options.Logger == nil {
+		return discardLogger
+	}
+	return rfs.Options.Logger
+}
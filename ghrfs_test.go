@@ -4,10 +4,27 @@
 package ghrfs
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"time"
 
+	"github.com/carabiner-dev/github"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,6 +71,70 @@ func TestCacheRelease(t *testing.T) {
 				require.FileExists(t, filepath.Join(o.CachePath, releaseDataFile))
 			},
 		},
+		{
+			"remote-fail", &ReleaseData{fileIndex: map[string]int{}}, true,
+			func(t *testing.T, o *Options, rd *ReleaseData) {
+				t.Helper()
+
+				f1, err := os.Create(filepath.Join(o.CachePath, "src-test1.txt"))
+				require.NoError(t, err)
+				_, err = f1.WriteString("test1")
+				require.NoError(t, err)
+				_, err = f1.Seek(0, 0)
+				require.NoError(t, err)
+
+				rd.Assets = append(rd.Assets, &AssetFile{
+					FileInfo:   FileInfo{IName: "test1.txt", ISize: int64(len("test1"))},
+					DataStream: f1,
+				})
+				rd.fileIndex["test1.txt"] = 0
+
+				// This asset has no data stream and no URL, so opening
+				// it remotely fails.
+				rd.Assets = append(rd.Assets, &AssetFile{
+					FileInfo: FileInfo{IName: "broken.txt", ISize: 10},
+				})
+				rd.fileIndex["broken.txt"] = 1
+			},
+			func(t *testing.T, o *Options, rd *ReleaseData) {
+				t.Helper()
+				require.FileExists(t, filepath.Join(o.CachePath, releaseDataFile))
+				require.FileExists(t, filepath.Join(o.CachePath, "test1.txt"))
+				require.NoFileExists(t, filepath.Join(o.CachePath, "broken.txt"))
+			},
+		},
+		{
+			"sidecar-collision", &ReleaseData{}, true,
+			func(t *testing.T, o *Options, rd *ReleaseData) {
+				t.Helper()
+				rd.Assets = append(rd.Assets, &AssetFile{
+					FileInfo:   FileInfo{IName: releaseDataFile, ISize: 2},
+					DataStream: io.NopCloser(strings.NewReader("{}")),
+				})
+			},
+			func(t *testing.T, o *Options, rd *ReleaseData) {
+				t.Helper()
+				require.NoFileExists(t, filepath.Join(o.CachePath, releaseDataFile))
+			},
+		},
+		{
+			"custom-sidecar", &ReleaseData{}, false,
+			func(t *testing.T, o *Options, rd *ReleaseData) {
+				t.Helper()
+				require.NoError(t, WithCacheMetadataName(".sidecar.json")(o))
+				rd.Assets = append(rd.Assets, &AssetFile{
+					FileInfo:   FileInfo{IName: releaseDataFile, ISize: 2},
+					DataStream: io.NopCloser(strings.NewReader("{}")),
+				})
+			},
+			func(t *testing.T, o *Options, rd *ReleaseData) {
+				t.Helper()
+				require.FileExists(t, filepath.Join(o.CachePath, ".sidecar.json"))
+				data, err := os.ReadFile(filepath.Join(o.CachePath, releaseDataFile))
+				require.NoError(t, err)
+				require.Equal(t, "{}", string(data))
+			},
+		},
 		// {"normal", &ReleaseData{}, func(t *testing.T, o *Options, rd *ReleaseData) {}, func(t *testing.T, o *Options, rd *ReleaseData) {}, false},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -74,8 +155,10 @@ func TestCacheRelease(t *testing.T) {
 			err := rfs.CacheRelease()
 			if tc.mustErr {
 				require.Error(t, err)
+				tc.validate(t, &rfs.Options, &rfs.Release)
 				return
 			}
+			require.NoError(t, err)
 
 			tc.validate(t, &rfs.Options, &rfs.Release)
 			for _, a := range rfs.Release.Assets {
@@ -88,3 +171,1293 @@ func TestCacheRelease(t *testing.T) {
 		})
 	}
 }
+
+func TestPreserveTimestamps(t *testing.T) {
+	t.Parallel()
+	mtime := time.Date(2024, 3, 14, 15, 9, 26, 0, time.UTC)
+	for _, tc := range []struct {
+		name     string
+		preserve bool
+	}{
+		{"preserve", true},
+		{"download-time", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs, _ := newRemoteTestFS(t, map[string][]byte{"test.txt": []byte("test data")})
+			rfs.Options.CachePath = t.TempDir()
+			rfs.Options.PreserveTimestamps = tc.preserve
+			rfs.Release.Assets[0].Mtime = mtime
+
+			require.NoError(t, rfs.CacheRelease())
+			info, err := os.Stat(filepath.Join(rfs.Options.CachePath, "test.txt"))
+			require.NoError(t, err)
+			if tc.preserve {
+				require.True(t, info.ModTime().Equal(mtime), "got mtime %s", info.ModTime())
+			} else {
+				require.False(t, info.ModTime().Equal(mtime))
+			}
+		})
+	}
+}
+
+func TestCacheAssets(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{
+		"a.txt":  []byte("a"),
+		"b.json": []byte("b"),
+		"c.json": []byte("c"),
+	}
+	for _, tc := range []struct {
+		name    string
+		assets  []string
+		cached  []string
+		mustErr bool
+	}{
+		{"allowlist", []string{"a.txt", "b.json"}, []string{"a.txt", "b.json"}, false},
+		{"no-list", nil, []string{"b.json", "c.json"}, false},
+		{"unknown", []string{"a.txt", "missing.txt"}, nil, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs, _ := newRemoteTestFS(t, files)
+			rfs.Options.CachePath = t.TempDir()
+			rfs.Options.CacheExtensions = []string{"json"}
+			require.NoError(t, WithCacheAssets(tc.assets...)(&rfs.Options))
+
+			err := rfs.CacheRelease()
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for name := range files {
+				if slices.Contains(tc.cached, name) {
+					require.FileExists(t, filepath.Join(rfs.Options.CachePath, name))
+				} else {
+					require.NoFileExists(t, filepath.Join(rfs.Options.CachePath, name))
+				}
+			}
+		})
+	}
+}
+
+func TestParallelDownloads(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{"a.txt": []byte("a"), "b.txt": []byte("b"), "c.txt": []byte("c")}
+	for _, tc := range []struct {
+		name     string
+		parallel int
+		mustErr  bool
+	}{
+		{"limited", 1, false},
+		{"unlimited", 0, false},
+		{"negative", -1, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs, _ := newRemoteTestFS(t, files)
+			rfs.Options.CachePath = t.TempDir()
+			err := WithParallelDownloads(tc.parallel)(&rfs.Options)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.NoError(t, rfs.CacheRelease())
+			for name := range files {
+				require.FileExists(t, filepath.Join(rfs.Options.CachePath, name))
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Counts the open file descriptors of the process
+func TestCacheReleaseClosesSidecar(t *testing.T) {
+	// openFiles returns the number of open descriptors, -1 if unknown
+	openFiles := func() int {
+		entries, err := os.ReadDir("/proc/self/fd")
+		if err != nil {
+			return -1
+		}
+		return len(entries)
+	}
+
+	before := openFiles()
+	for i := range 100 {
+		rfs := &ReleaseFileSystem{
+			Options: Options{CachePath: t.TempDir(), ParallelDownloads: 1},
+			Release: ReleaseData{ID: int64(i), Tag: fmt.Sprintf("v%d.0.0", i)},
+		}
+		require.NoError(t, rfs.CacheRelease())
+
+		// Read the sidecar back, it must be complete
+		data, err := os.ReadFile(filepath.Join(rfs.Options.CachePath, releaseDataFile))
+		require.NoError(t, err)
+		rd := ReleaseData{}
+		require.NoError(t, json.Unmarshal(data, &rd)) //nolint:musttag
+		require.Equal(t, int64(i), rd.ID)
+		require.Equal(t, fmt.Sprintf("v%d.0.0", i), rd.Tag)
+	}
+
+	if before != -1 {
+		require.Less(t, openFiles()-before, 10, "file descriptors leaked")
+	}
+}
+
+func TestIndexAssets(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		filter func(*AssetFile) bool
+		expect []string
+	}{
+		{"no-filter", nil, []string{"tool-linux-amd64", "tool-darwin-arm64", "README.md"}},
+		{
+			"filter", func(a *AssetFile) bool { return strings.HasPrefix(a.Name(), "tool-linux") },
+			[]string{"tool-linux-amd64"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := &ReleaseFileSystem{
+				Options: Options{AssetFilter: tc.filter},
+			}
+			for _, name := range []string{"tool-linux-amd64", "tool-darwin-arm64", "README.md"} {
+				rfs.Release.Assets = append(rfs.Release.Assets, &AssetFile{FileInfo: FileInfo{IName: name}})
+			}
+			rfs.indexAssets()
+
+			require.Len(t, rfs.Release.Assets, len(tc.expect))
+			require.Len(t, rfs.Release.fileIndex, len(tc.expect))
+			entries, err := rfs.ReadDir(".")
+			require.NoError(t, err)
+			require.Len(t, entries, len(tc.expect))
+			for _, name := range tc.expect {
+				_, err := rfs.Stat(name)
+				require.NoError(t, err)
+			}
+			if tc.filter != nil {
+				_, err := rfs.Open("README.md")
+				require.ErrorIs(t, err, fs.ErrNotExist)
+			}
+		})
+	}
+}
+
+// newCachedTestFS returns a release filesystem backed by a cache directory
+// populated with the specified files.
+func newCachedTestFS(t *testing.T, files map[string][]byte) *ReleaseFileSystem {
+	t.Helper()
+	cache := t.TempDir()
+	rfs := &ReleaseFileSystem{
+		Options: defaultOptions,
+	}
+	rfs.Options.Cache = true
+	rfs.Options.CachePath = cache
+
+	for name, data := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(cache, name), data, 0o600))
+		rfs.Release.Assets = append(rfs.Release.Assets, &AssetFile{
+			FileInfo: FileInfo{IName: name, ISize: int64(len(data))},
+		})
+	}
+	rfs.indexAssets()
+	return rfs
+}
+
+func TestCacheLayout(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	filesystems := map[string]*ReleaseFileSystem{}
+	for _, tag := range []string{"v1.0.0", "v2.0.0"} {
+		rfs := &ReleaseFileSystem{Options: defaultOptions}
+		require.NoError(t, WithCacheLayout(CacheLayoutByTag)(&rfs.Options))
+		rfs.Options.CachePath = root
+		rfs.Options.Organization = "example"
+		rfs.Options.Repository = "repo"
+		rfs.Release.Tag = tag
+		rfs.Release.Assets = []*AssetFile{{
+			FileInfo:   FileInfo{IName: "notes.txt", ISize: int64(len(tag))},
+			DataStream: newMemoryStream([]byte(tag)),
+		}}
+		rfs.indexAssets()
+		require.NoError(t, rfs.CacheRelease())
+		filesystems[tag] = rfs
+	}
+
+	for tag, rfs := range filesystems {
+		dir := filepath.Join(root, "example", "repo", tag)
+		require.FileExists(t, filepath.Join(dir, releaseDataFile))
+
+		f, err := rfs.OpenCachedFile("notes.txt")
+		require.NoError(t, err)
+		data, err := io.ReadAll(f)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.Equal(t, tag, string(data))
+	}
+	require.NoFileExists(t, filepath.Join(root, "notes.txt"))
+	require.Error(t, WithCacheLayout("nested")(&Options{}))
+}
+
+func TestCacheSidecarHidden(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{
+		"asset.txt":     []byte("data"),
+		releaseDataFile: []byte("{}"),
+	})
+
+	visited := []string{}
+	require.NoError(t, fs.WalkDir(rfs, ".", func(path string, d fs.DirEntry, err error) error {
+		visited = append(visited, path)
+		return err
+	}))
+	require.Equal(t, []string{".", "asset.txt"}, visited)
+
+	_, err := rfs.Open(releaseDataFile)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+// newRemoteTestFS returns a release filesystem whose assets are served
+// by a fake caller with the specified data.
+func newRemoteTestFS(t *testing.T, files map[string][]byte) (*ReleaseFileSystem, *fakeCaller) {
+	t.Helper()
+	caller := &fakeCaller{responses: map[string]fakeResponse{}}
+	rfs := &ReleaseFileSystem{
+		Options:     defaultOptions,
+		assetCaller: caller,
+	}
+	rfs.Options.Organization = "example"
+	rfs.Options.Repository = "repo"
+	rfs.Release.Tag = "v1.0.0"
+
+	for name, data := range files {
+		url := "https://github.com/example/repo/releases/download/v1.0.0/" + name
+		caller.responses[url] = fakeResponse{http.StatusOK, string(data)}
+		rfs.Release.Assets = append(rfs.Release.Assets, &AssetFile{
+			URL: url, FileInfo: FileInfo{IName: name, ISize: int64(len(data))},
+		})
+	}
+	rfs.indexAssets()
+	return rfs, caller
+}
+
+func TestFromReleaseJSON(t *testing.T) {
+	t.Parallel()
+	const assetURL = "https://github.com/example/repo/releases/download/v1.0.0/notes.txt"
+	release := `{"id": 42, "tag_name": "v1.0.0", "draft": %t, "assets": [
+		{"id": 1, "name": "notes.txt", "size": 5, "browser_download_url": "` + assetURL + `"}
+	]}`
+
+	t.Run("remote", func(t *testing.T) {
+		t.Parallel()
+		rfs, err := FromReleaseJSON(strings.NewReader(fmt.Sprintf(release, false)))
+		require.NoError(t, err)
+		require.Equal(t, "v1.0.0", rfs.ResolvedTag())
+
+		caller := &fakeCaller{responses: map[string]fakeResponse{
+			assetURL: {http.StatusOK, "hello"},
+		}}
+		rfs.assetCaller = caller
+
+		data, err := fs.ReadFile(rfs, "notes.txt")
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(data))
+	})
+
+	t.Run("draft", func(t *testing.T) {
+		t.Parallel()
+		_, err := FromReleaseJSON(strings.NewReader(fmt.Sprintf(release, true)))
+		require.ErrorIs(t, err, ErrDraftRelease)
+
+		_, err = FromReleaseJSON(strings.NewReader(fmt.Sprintf(release, true)), WithIncludeDrafts(true))
+		require.NoError(t, err)
+	})
+
+	t.Run("require-published", func(t *testing.T) {
+		t.Parallel()
+		for _, tc := range []struct {
+			name    string
+			json    string
+			mustErr bool
+		}{
+			{"published", `{"tag_name": "v1.0.0", "published_at": "2025-01-10T00:00:00Z"}`, false},
+			{"unpublished", `{"tag_name": "v1.0.0"}`, true},
+			{"draft", `{"tag_name": "v1.0.0", "draft": true, "published_at": "2025-01-10T00:00:00Z"}`, true},
+		} {
+			_, err := FromReleaseJSON(
+				strings.NewReader(tc.json), WithIncludeDrafts(true), WithRequirePublished(true),
+			)
+			if tc.mustErr {
+				require.ErrorIs(t, err, ErrUnpublishedRelease, tc.name)
+				continue
+			}
+			require.NoError(t, err, tc.name)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		_, err := FromReleaseJSON(strings.NewReader("not json"))
+		require.Error(t, err)
+	})
+}
+
+func TestOpenAutoDecompress(t *testing.T) {
+	t.Parallel()
+	plain := []byte(`{"msg":"hello"}` + "\n" + `{"msg":"bye"}` + "\n")
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(plain)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	for _, tc := range []struct {
+		name       string
+		decompress bool
+		suffixes   []string
+		expect     []byte
+	}{
+		{"off", false, nil, buf.Bytes()},
+		{"on", true, nil, plain},
+		{"custom-suffix-unmatched", true, []string{".gzip"}, buf.Bytes()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := newCachedTestFS(t, map[string][]byte{"logs.json.gz": buf.Bytes()})
+			rfs.Options.AutoDecompress = tc.decompress
+			if tc.suffixes != nil {
+				rfs.Options.CompressedSuffixes = tc.suffixes
+			}
+
+			f, err := rfs.Open("logs.json.gz")
+			require.NoError(t, err)
+			info, err := f.Stat()
+			require.NoError(t, err)
+			require.Equal(t, int64(len(tc.expect)), info.Size())
+
+			data, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, data)
+			require.NoError(t, f.Close())
+		})
+	}
+}
+
+func TestFailOnMissingCache(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		cached   map[string]string
+		fail     bool
+		mustErr  bool
+		expected string
+	}{
+		{"hit", map[string]string{"test.txt": "hello"}, true, false, "hello"},
+		{"missing-fallback", map[string]string{}, false, false, "hello"},
+		{"missing-fail", map[string]string{}, true, true, ""},
+		{"size-mismatch-fail", map[string]string{"test.txt": "hi"}, true, true, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs, caller := newRemoteTestFS(t, map[string][]byte{"test.txt": []byte("hello")})
+			rfs.Options.Cache = true
+			rfs.Options.CachePath = t.TempDir()
+			require.NoError(t, WithFailOnMissingCache(tc.fail)(&rfs.Options))
+			for name, data := range tc.cached {
+				require.NoError(t, os.WriteFile(filepath.Join(rfs.Options.CachePath, name), []byte(data), 0o600))
+			}
+
+			data, err := fs.ReadFile(rfs, "test.txt")
+			if tc.mustErr {
+				require.ErrorIs(t, err, ErrCacheMiss)
+				require.Empty(t, caller.requests)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(data))
+		})
+	}
+}
+
+func TestOpenCachedFileSize(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		data    string
+		strict  bool
+		errText string
+	}{
+		{"match", "test1", false, ""},
+		{"truncated", "tes", false, "no URL found"},
+		{"oversized", "test1 and more", false, "no URL found"},
+		{"truncated-strict", "tes", true, "expected 5"},
+		{"oversized-strict", "test1 and more", true, "expected 5"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := newCachedTestFS(t, map[string][]byte{"test1.txt": []byte(tc.data)})
+			rfs.Options.StrictCache = tc.strict
+			// Record the expected asset size
+			rfs.Release.Assets[0].ISize = int64(len("test1"))
+
+			f, err := rfs.OpenCachedFile("test1.txt")
+			if tc.errText != "" {
+				// Non strict mismatches fall back to the remote file, which
+				// fails as the test assets have no URL.
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errText)
+				return
+			}
+			require.NoError(t, err)
+			data, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.Equal(t, tc.data, string(data))
+			require.NoError(t, f.Close())
+		})
+	}
+}
+
+// fakeCaller implements github.Caller returning canned responses keyed by
+// the requested endpoint. Unknown endpoints return a 404 error.
+type fakeCaller struct {
+	mtx       sync.Mutex
+	responses map[string]fakeResponse
+	headers   map[string]http.Header
+	requests  []string
+	delay     time.Duration
+}
+
+type fakeResponse struct {
+	status int
+	body   string
+}
+
+func (fc *fakeCaller) RequestWithContext(ctx context.Context, _, endpoint string, _ io.Reader) (*http.Response, error) {
+	fc.mtx.Lock()
+	fc.requests = append(fc.requests, endpoint)
+	fc.mtx.Unlock()
+
+	// Simulate a slow server, honoring the context
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(fc.delay):
+	}
+
+	r, ok := fc.responses[endpoint]
+	if !ok {
+		r = fakeResponse{status: http.StatusNotFound, body: `{"message":"Not Found"}`}
+	}
+
+	resp := &http.Response{
+		StatusCode:    r.status,
+		Body:          io.NopCloser(strings.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Header:        fc.headers[endpoint].Clone(),
+	}
+	if resp.Header == nil {
+		resp.Header = http.Header{}
+	}
+	if u, err := url.Parse(endpoint); err == nil {
+		resp.Request = &http.Request{Method: http.MethodGet, URL: u}
+	}
+	if r.status < 200 || r.status > 399 {
+		return resp, fmt.Errorf("HTTP Error %d sending request", r.status)
+	}
+	return resp, nil
+}
+
+// Call implements apiCaller so the fake can stand in for the API client.
+func (fc *fakeCaller) Call(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	return fc.RequestWithContext(ctx, method, path, body)
+}
+
+func TestLoadRelease(t *testing.T) {
+	t.Parallel()
+	const (
+		tagEndpoint    = "repos/example/repo/releases/tags/v1.0.0"
+		latestEndpoint = "repos/example/repo/releases/latest"
+		release        = `{"id": 42, "tag_name": "%s", "assets": [{"id": 1, "name": "notes.txt", "size": 5}]}`
+	)
+
+	// A first page full of old releases forces fetching the second one
+	page1 := make([]string, 0, releasesPageSize)
+	for i := range releasesPageSize {
+		page1 = append(page1, fmt.Sprintf(`{"id": %d, "tag_name": "v0.%d.0"}`, i+100, i))
+	}
+
+	for _, tc := range []struct {
+		name      string
+		tag       string
+		semver    bool
+		responses map[string]fakeResponse
+		expectTag string
+		expectErr error
+		mustErr   bool
+	}{
+		{
+			"ok", "v1.0.0", false,
+			map[string]fakeResponse{tagEndpoint: {http.StatusOK, fmt.Sprintf(release, "v1.0.0")}},
+			"v1.0.0", nil, false,
+		},
+		{
+			"latest", "", false,
+			map[string]fakeResponse{latestEndpoint: {http.StatusOK, fmt.Sprintf(release, "v2.0.0")}},
+			"v2.0.0", nil, false,
+		},
+		{
+			"not-found", "", false,
+			map[string]fakeResponse{}, "", ErrReleaseNotFound, true,
+		},
+		{
+			"server-error", "v1.0.0", false,
+			map[string]fakeResponse{tagEndpoint: {http.StatusInternalServerError, `{"message":"boom"}`}},
+			"", nil, true,
+		},
+		{
+			"invalid-json", "v1.0.0", false,
+			map[string]fakeResponse{tagEndpoint: {http.StatusOK, `{"id": `}},
+			"", nil, true,
+		},
+		{
+			"semver-pagination", "", true,
+			map[string]fakeResponse{
+				"repos/example/repo/releases?per_page=100&page=1": {http.StatusOK, "[" + strings.Join(page1, ",") + "]"},
+				"repos/example/repo/releases?per_page=100&page=2": {http.StatusOK, `[{"id": 1, "tag_name": "v1.0.0"}]`},
+				tagEndpoint: {http.StatusOK, fmt.Sprintf(release, "v1.0.0")},
+			},
+			"v1.0.0", nil, false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			caller := &fakeCaller{responses: tc.responses}
+			rfs := &ReleaseFileSystem{Options: defaultOptions, client: caller}
+			rfs.Options.Organization = "example"
+			rfs.Options.Repository = "repo"
+			rfs.Options.Tag = tc.tag
+			rfs.Options.LatestSemver = tc.semver
+
+			err := rfs.LoadRelease()
+			if tc.mustErr {
+				require.Error(t, err)
+				if tc.expectErr != nil {
+					require.ErrorIs(t, err, tc.expectErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectTag, rfs.ResolvedTag())
+			require.Equal(t, 1, rfs.AssetCount())
+		})
+	}
+}
+
+func TestResponseTap(t *testing.T) {
+	t.Parallel()
+	const endpoint = "repos/example/repo/releases/tags/v1.0.0"
+	for _, tc := range []struct {
+		name    string
+		body    string
+		mustErr bool
+	}{
+		{"ok", `{"id": 42, "tag_name": "v1.0.0", "assets": []}`, false},
+		{"invalid-json", `{"id": "forty-two"}`, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var tapped []byte
+			caller := &fakeCaller{responses: map[string]fakeResponse{endpoint: {http.StatusOK, tc.body}}}
+			rfs := &ReleaseFileSystem{Options: defaultOptions, client: caller}
+			rfs.Options.Organization = "example"
+			rfs.Options.Repository = "repo"
+			rfs.Options.Tag = "v1.0.0"
+			require.NoError(t, WithResponseTap(func(b []byte) { tapped = b })(&rfs.Options))
+
+			err := rfs.LoadRelease()
+			if tc.mustErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, int64(42), rfs.Release.ID)
+			}
+
+			// The tap sees the body even when it can't be decoded
+			require.Equal(t, tc.body, string(tapped))
+		})
+	}
+}
+
+func TestOpenRemoteFileMirror(t *testing.T) {
+	t.Parallel()
+	const (
+		assetURL  = "https://github.com/example/repo/releases/download/v1.0.0/test.txt"
+		mirrorURL = "https://mirror.example.com/example/repo/v1.0.0/test.txt"
+	)
+	for _, tc := range []struct {
+		name      string
+		responses map[string]fakeResponse
+		expect    string
+		requests  []string
+	}{
+		{
+			"mirror-ok",
+			map[string]fakeResponse{
+				mirrorURL: {http.StatusOK, "from mirror"},
+				assetURL:  {http.StatusOK, "from github"},
+			},
+			"from mirror", []string{mirrorURL},
+		},
+		{
+			"mirror-fails",
+			map[string]fakeResponse{
+				mirrorURL: {http.StatusBadGateway, "oops"},
+				assetURL:  {http.StatusOK, "from github"},
+			},
+			"from github", []string{mirrorURL, assetURL},
+		},
+		{
+			"mirror-404",
+			map[string]fakeResponse{assetURL: {http.StatusOK, "from github"}},
+			"from github", []string{mirrorURL, assetURL},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			caller := &fakeCaller{responses: tc.responses}
+			rfs := &ReleaseFileSystem{
+				Options: Options{
+					Organization: "example",
+					Repository:   "repo",
+					Mirror:       "https://mirror.example.com/{org}/{repo}/{tag}/{name}",
+				},
+				Release: ReleaseData{
+					Tag:    "v1.0.0",
+					Assets: []*AssetFile{{URL: assetURL, FileInfo: FileInfo{IName: "test.txt"}}},
+				},
+				assetCaller: caller,
+			}
+			rfs.indexAssets()
+
+			f, err := rfs.OpenRemoteFile("test.txt")
+			require.NoError(t, err)
+			data, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, string(data))
+			require.Equal(t, tc.requests, caller.requests)
+		})
+	}
+}
+
+func TestLengthChecker(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		data     string
+		expected int64
+		mustErr  bool
+	}{
+		{"exact", "0123456789", 10, false},
+		{"empty", "", 0, false},
+		{"truncated", "01234", 10, true},
+		{"too-long", "0123456789ab", 10, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			lc := &lengthChecker{
+				ReadCloser: io.NopCloser(strings.NewReader(tc.data)),
+				name:       "test.txt",
+				expected:   tc.expected,
+			}
+			data, err := io.ReadAll(lc)
+			require.Equal(t, tc.data, string(data))
+			if tc.mustErr {
+				require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestStrictSize(t *testing.T) {
+	t.Parallel()
+	const mirrorURL = "https://mirror.example.com/test.txt"
+	for _, tc := range []struct {
+		name    string
+		size    int64
+		strict  bool
+		mirror  string
+		expect  string
+		mustErr bool
+	}{
+		{"match", 10, true, "", "0123456789", false},
+		{"mismatch", 12, true, "", "", true},
+		{"mismatch-lenient", 12, false, "", "0123456789", false},
+		{"unknown-size", -1, true, "", "0123456789", false},
+		{"mirror-mismatch-falls-back", 10, true, "012345", "0123456789", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs, caller := newRemoteTestFS(t, map[string][]byte{"test.txt": []byte("0123456789")})
+			rfs.Options.StrictSize = tc.strict
+			rfs.Release.Assets[0].ISize = tc.size
+			if tc.mirror != "" {
+				rfs.Options.Mirror = mirrorURL
+				caller.responses[mirrorURL] = fakeResponse{http.StatusOK, tc.mirror}
+			}
+
+			f, err := rfs.OpenRemoteFile("test.txt")
+			if tc.mustErr {
+				require.ErrorIs(t, err, ErrSizeMismatch)
+				return
+			}
+			require.NoError(t, err)
+			defer f.Close() //nolint:errcheck
+			data, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, string(data))
+		})
+	}
+}
+
+func TestOpenRemoteFileContext(t *testing.T) {
+	t.Parallel()
+	rfs, _ := newRemoteTestFS(t, map[string][]byte{"test.txt": []byte("0123456789")})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f, err := rfs.OpenRemoteFileContext(ctx, "test.txt")
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+
+	buf := make([]byte, 4)
+	n, err := f.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "0123", string(buf[:n]))
+
+	cancel()
+	_, err = f.Read(buf)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMaxDownloadSize(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		data    string
+		size    int64
+		limit   int64
+		mustErr bool
+	}{
+		{"no-limit", "0123456789", 10, 0, false},
+		{"under-limit", "01234", 5, 5, false},
+		{"over-limit", "0123456789", 10, 5, true},
+		{"unknown-size-under", "0123", 0, 5, false},
+		{"unknown-size-over", "0123456789", 0, 5, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs, _ := newRemoteTestFS(t, map[string][]byte{"test.txt": []byte(tc.data)})
+			rfs.Options.MaxDownloadSize = tc.limit
+			rfs.Release.Assets[0].ISize = tc.size
+
+			f, err := rfs.OpenRemoteFile("test.txt")
+			require.NoError(t, err)
+			defer f.Close() //nolint:errcheck
+			data, err := io.ReadAll(f)
+			if tc.mustErr {
+				require.ErrorIs(t, err, ErrDownloadTooLarge)
+				require.Len(t, data, int(tc.limit))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.data, string(data))
+		})
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	t.Parallel()
+	const assetURL = "https://github.com/example/repo/releases/download/v1.0.0/test.txt"
+	for _, tc := range []struct {
+		name            string
+		timeout         time.Duration
+		downloadTimeout time.Duration
+		mustErr         bool
+	}{
+		{"no-timeout", 0, 0, false},
+		{"metadata-timeout", 10 * time.Millisecond, 0, true},
+		{"download-timeout", 0, 10 * time.Millisecond, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			caller := &fakeCaller{
+				delay: 100 * time.Millisecond,
+				responses: map[string]fakeResponse{
+					"repos/example/repo/releases/tags/v1.0.0": {
+						http.StatusOK, `{"tag_name":"v1.0.0","assets":[{"name":"test.txt","browser_download_url":"` + assetURL + `"}]}`,
+					},
+					assetURL: {http.StatusOK, "hello"},
+				},
+			}
+			client, err := github.NewClient(github.WithCaller(caller))
+			require.NoError(t, err)
+
+			rfs := &ReleaseFileSystem{
+				Options: Options{
+					Organization:    "example",
+					Repository:      "repo",
+					Tag:             "v1.0.0",
+					Timeout:         tc.timeout,
+					DownloadTimeout: tc.downloadTimeout,
+				},
+				client:      client,
+				assetCaller: caller,
+			}
+
+			err = rfs.LoadRelease()
+			if tc.timeout > 0 {
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+				return
+			}
+			require.NoError(t, err)
+
+			f, err := rfs.Open("test.txt")
+			if tc.mustErr {
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+		})
+	}
+}
+
+func TestCacheReleaseDownloadTimeout(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow.bin" {
+			// Send part of the body and hang until the client gives up
+			w.Header().Set("Content-Length", "10")
+			w.Write([]byte("slow")) //nolint:errcheck,gosec
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte("fast")) //nolint:errcheck,gosec
+	}))
+	t.Cleanup(srv.Close)
+
+	rfs := &ReleaseFileSystem{Options: defaultOptions}
+	rfs.Options.CachePath = t.TempDir()
+	rfs.Options.DownloadTimeout = 100 * time.Millisecond
+	rfs.Release.Assets = []*AssetFile{
+		{URL: srv.URL + "/slow.bin", FileInfo: FileInfo{IName: "slow.bin", ISize: 10}},
+		{URL: srv.URL + "/fast.bin", FileInfo: FileInfo{IName: "fast.bin", ISize: 4}},
+	}
+	rfs.indexAssets()
+
+	start := time.Now()
+	err := rfs.CacheRelease()
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Error(t, err)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, err.Error(), "slow.bin")
+
+	data, err := os.ReadFile(filepath.Join(rfs.Options.CachePath, "fast.bin"))
+	require.NoError(t, err)
+	require.Equal(t, "fast", string(data))
+}
+
+func TestURLRewriter(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/example/repo/releases/download/v1.0.0/test.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("local")) //nolint:errcheck,gosec
+	}))
+	t.Cleanup(srv.Close)
+
+	rfs := &ReleaseFileSystem{Options: defaultOptions}
+	rfs.Options.URLRewriter = func(u string) string {
+		return strings.Replace(u, "https://github.com", srv.URL, 1)
+	}
+	rfs.Release.Assets = []*AssetFile{{
+		URL:      "https://github.com/example/repo/releases/download/v1.0.0/test.txt",
+		FileInfo: FileInfo{IName: "test.txt", ISize: 5},
+	}}
+	rfs.indexAssets()
+
+	data, err := fs.ReadFile(rfs, "test.txt")
+	require.NoError(t, err)
+	require.Equal(t, "local", string(data))
+
+	rfs.Options.CachePath = t.TempDir()
+	require.NoError(t, rfs.CacheRelease())
+	data, err = os.ReadFile(filepath.Join(rfs.Options.CachePath, "test.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "local", string(data))
+}
+
+func TestDownloadVia(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/download/test.txt":
+			w.Write([]byte("browser")) //nolint:errcheck,gosec
+		case "/api/assets/1":
+			if r.Header.Get("Accept") != "application/octet-stream" {
+				w.Write([]byte(`{"id":1}`)) //nolint:errcheck,gosec
+				return
+			}
+			http.Redirect(w, r, "/storage/test.txt", http.StatusFound)
+		case "/storage/test.txt":
+			w.Write([]byte("via-api")) //nolint:errcheck,gosec
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	for _, tc := range []struct {
+		name   string
+		via    string
+		expect string
+	}{
+		{"browser", DownloadViaBrowser, "browser"},
+		{"api", DownloadViaAPI, "via-api"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := &ReleaseFileSystem{Options: defaultOptions}
+			require.NoError(t, WithDownloadVia(tc.via)(&rfs.Options))
+			rfs.Release.Assets = []*AssetFile{{
+				URL:      srv.URL + "/download/test.txt",
+				APIURL:   srv.URL + "/api/assets/1",
+				FileInfo: FileInfo{IName: "test.txt", ISize: 7},
+			}}
+			rfs.indexAssets()
+
+			data, err := fs.ReadFile(rfs, "test.txt")
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, string(data))
+		})
+	}
+
+	require.Error(t, WithDownloadVia("ftp")(&Options{}))
+}
+
+func TestReleasePath(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		opts    Options
+		expect  string
+		mustErr bool
+	}{
+		{"tag", Options{Tag: "v1.0.0"}, "repos/example/repo/releases/tags/v1.0.0", false},
+		{"empty-tag", Options{}, "repos/example/repo/releases/latest", false},
+		{"latest", Options{Tag: "latest"}, "repos/example/repo/releases/latest", false},
+		{"id", Options{ReleaseID: 1234}, "repos/example/repo/releases/1234", false},
+		{"id-over-tag", Options{ReleaseID: 1234, Tag: "v1.0.0"}, "repos/example/repo/releases/1234", false},
+		{"negative-id", Options{ReleaseID: -1}, "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.opts.Organization = "example"
+			tc.opts.Repository = "repo"
+			rfs := &ReleaseFileSystem{Options: tc.opts}
+			path, err := rfs.releasePath(t.Context())
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, path)
+		})
+	}
+}
+
+func TestReleaseInfo(t *testing.T) {
+	t.Parallel()
+	releaseJSON := `{"id":1,"tag_name":"v1.0.0","name":"First Release","body":"## Changelog\n- Stuff",` +
+		`"html_url":"https://github.com/example/repo/releases/tag/v1.0.0","prerelease":true,` +
+		`"author":{"login":"octocat","id":1},"assets":[]}`
+	caller := &fakeCaller{responses: map[string]fakeResponse{
+		"repos/example/repo/releases/tags/v1.0.0": {http.StatusOK, releaseJSON},
+	}}
+	client, err := github.NewClient(github.WithCaller(caller))
+	require.NoError(t, err)
+
+	rfs := &ReleaseFileSystem{
+		Options: Options{
+			Organization: "example", Repository: "repo", Tag: "v1.0.0",
+			Cache: true, CachePath: t.TempDir(), ParallelDownloads: 1,
+		},
+		client: client,
+	}
+	require.NoError(t, rfs.LoadRelease())
+
+	info := rfs.ReleaseInfo()
+	require.Equal(t, "First Release", info.Name)
+	require.Equal(t, "## Changelog\n- Stuff", info.Body)
+	require.Equal(t, "octocat", info.Author)
+	require.Equal(t, "https://github.com/example/repo/releases/tag/v1.0.0", info.HTMLURL)
+	require.True(t, info.Prerelease)
+
+	// Check the new fields round trip through the cached release data
+	data, err := os.ReadFile(filepath.Join(rfs.Options.CachePath, releaseDataFile))
+	require.NoError(t, err)
+	cached := ReleaseData{}
+	require.NoError(t, json.Unmarshal(data, &cached)) //nolint:musttag
+	require.Equal(t, rfs.Release.Name, cached.Name)
+	require.Equal(t, rfs.Release.Body, cached.Body)
+	require.Equal(t, rfs.Release.HTMLURL, cached.HTMLURL)
+	require.Equal(t, rfs.Release.Prerelease, cached.Prerelease)
+	require.Equal(t, rfs.Release.Author, cached.Author)
+}
+
+func TestAssetURL(t *testing.T) {
+	t.Parallel()
+	rfs := &ReleaseFileSystem{}
+	require.NoError(t, json.Unmarshal([]byte(`{"assets": [{
+		"id": 1234,
+		"name": "tool.tar.gz",
+		"url": "https://api.github.com/repos/example/repo/releases/assets/1234",
+		"browser_download_url": "https://github.com/example/repo/releases/download/v1.0.0/tool.tar.gz"
+	}]}`), &rfs.Release)) //nolint:musttag
+	rfs.indexAssets()
+
+	u, err := rfs.AssetURL("tool.tar.gz")
+	require.NoError(t, err)
+	require.Equal(t, "https://github.com/example/repo/releases/download/v1.0.0/tool.tar.gz", u)
+
+	u, err = rfs.AssetAPIURL("tool.tar.gz")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.github.com/repos/example/repo/releases/assets/1234", u)
+
+	_, err = rfs.AssetURL("missing.tar.gz")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = rfs.AssetAPIURL("missing.tar.gz")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestResolvedTag(t *testing.T) {
+	t.Parallel()
+	for _, tag := range []string{"", "latest"} {
+		client, err := github.NewClient(github.WithCaller(&fakeCaller{responses: map[string]fakeResponse{
+			"repos/example/repo/releases/latest": {http.StatusOK, `{"tag_name":"v1.2.3","assets":[]}`},
+		}}))
+		require.NoError(t, err)
+		rfs := &ReleaseFileSystem{
+			Options: Options{Organization: "example", Repository: "repo", Tag: tag},
+			client:  client,
+		}
+		require.NoError(t, rfs.LoadRelease())
+		require.Equal(t, "v1.2.3", rfs.ResolvedTag())
+		require.Equal(t, tag, rfs.Options.Tag)
+	}
+}
+
+func TestReleaseStats(t *testing.T) {
+	t.Parallel()
+	rfs := &ReleaseFileSystem{}
+	require.Zero(t, rfs.AssetCount())
+	require.Empty(t, rfs.AssetNames())
+	require.Zero(t, rfs.TotalSize())
+	require.Nil(t, rfs.LargestAsset())
+
+	for name, size := range map[string]int64{"a.txt": 10, "b.tar.gz": 300, "c.json": 25} {
+		rfs.Release.Assets = append(rfs.Release.Assets, &AssetFile{FileInfo: FileInfo{IName: name, ISize: size}})
+	}
+	require.Equal(t, 3, rfs.AssetCount())
+	require.Equal(t, []string{"a.txt", "b.tar.gz", "c.json"}, rfs.AssetNames())
+	require.Equal(t, int64(335), rfs.TotalSize())
+	require.Equal(t, "b.tar.gz", rfs.LargestAsset().Name())
+}
+
+func TestInvalidPaths(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{"test.txt": []byte("test")})
+	for _, tc := range []struct {
+		name   string
+		path   string
+		expect error
+	}{
+		{"dot-slash", "./test.txt", fs.ErrInvalid},
+		{"trailing-slash", "test.txt/", fs.ErrInvalid},
+		{"absolute", "/test.txt", fs.ErrInvalid},
+		{"root-slash", "/", fs.ErrInvalid},
+		{"parent", "../test.txt", fs.ErrInvalid},
+		{"missing", "nope.txt", fs.ErrNotExist},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var pathErr *fs.PathError
+
+			_, err := rfs.Open(tc.path)
+			require.ErrorIs(t, err, tc.expect)
+			require.ErrorAs(t, err, &pathErr)
+			require.Equal(t, "open", pathErr.Op)
+			require.Equal(t, tc.path, pathErr.Path)
+
+			_, err = rfs.Stat(tc.path)
+			require.ErrorIs(t, err, tc.expect)
+			require.ErrorAs(t, err, &pathErr)
+			require.Equal(t, "stat", pathErr.Op)
+
+			_, err = rfs.ReadDir(tc.path)
+			require.ErrorIs(t, err, tc.expect)
+			require.ErrorAs(t, err, &pathErr)
+			require.Equal(t, "readdir", pathErr.Op)
+		})
+	}
+}
+
+func TestFSCompliance(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{
+		"about-this-release.txt": []byte("This is a test release\n"),
+		"data.json":              []byte(`{"hello":"world"}`),
+		"empty.bin":              {},
+	}
+	names := []string{"about-this-release.txt", "data.json", "empty.bin"}
+
+	for _, tc := range []struct {
+		name    string
+		prepare func(*testing.T) *ReleaseFileSystem
+		expect  []string
+	}{
+		{
+			"cached", func(t *testing.T) *ReleaseFileSystem {
+				t.Helper()
+				return newCachedTestFS(t, files)
+			}, names,
+		},
+		{
+			"metadata-file", func(t *testing.T) *ReleaseFileSystem {
+				t.Helper()
+				rfs := newCachedTestFS(t, files)
+				rfs.Options.ExposeMetadataFile = true
+				return rfs
+			}, append([]string{defaultMetadataFileName}, names...),
+		},
+		{
+			"remote", func(t *testing.T) *ReleaseFileSystem {
+				t.Helper()
+				rfs, _ := newRemoteTestFS(t, files)
+				return rfs
+			}, names,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.NoError(t, fstest.TestFS(tc.prepare(t), tc.expect...))
+		})
+	}
+}
+
+func TestCaseInsensitiveLookup(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name        string
+		insensitive bool
+		path        string
+		expect      string
+		mustErr     error
+	}{
+		{"sensitive-exact", false, "Setup.EXE", "setup-exe", nil},
+		{"sensitive-miss", false, "setup.exe", "", fs.ErrNotExist},
+		{"insensitive", true, "SETUP.exe", "setup-exe", nil},
+		{"insensitive-exact-wins", true, "README.md", "readme-upper", nil},
+		{"insensitive-ambiguous", true, "Readme.MD", "", ErrAmbiguousName},
+		{"insensitive-miss", true, "nope.txt", "", fs.ErrNotExist},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := newCachedTestFS(t, map[string][]byte{
+				"Setup.EXE": []byte("setup-exe"),
+				"README.md": []byte("readme-upper"),
+				"readme.md": []byte("readme-lower"),
+			})
+			rfs.Options.CaseInsensitiveLookup = tc.insensitive
+			rfs.indexAssets()
+
+			_, statErr := rfs.Stat(tc.path)
+			f, err := rfs.Open(tc.path)
+			if tc.mustErr != nil {
+				require.ErrorIs(t, err, tc.mustErr)
+				require.ErrorIs(t, statErr, tc.mustErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, statErr)
+			data, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, string(data))
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	t.Parallel()
+	large := bytes.Repeat([]byte("0123456789"), 1000)
+	rfs := newCachedTestFS(t, map[string][]byte{
+		"small.txt": []byte("hello"),
+		"large.bin": large,
+		"empty.txt": {},
+	})
+
+	for _, tc := range []struct {
+		name    string
+		path    string
+		expect  []byte
+		mustErr bool
+	}{
+		{"small", "small.txt", []byte("hello"), false},
+		{"large", "large.bin", large, false},
+		{"empty", "empty.txt", []byte{}, false},
+		{"missing", "missing.txt", nil, true},
+		{"dir", ".", nil, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			data, err := fs.ReadFile(rfs, tc.path)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, data)
+		})
+	}
+}
+
+func TestLogging(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	rfs, _ := newRemoteTestFS(t, map[string][]byte{"test.txt": []byte("test data")})
+	rfs.Options.Logger = logger
+	rfs.Options.CachePath = t.TempDir()
+
+	// Opening the file before caching it fetches it remotely
+	data, err := fs.ReadFile(rfs, "test.txt")
+	require.NoError(t, err)
+	require.Equal(t, "test data", string(data))
+	require.Contains(t, buf.String(), `msg="fetched asset" asset=test.txt`)
+
+	require.NoError(t, rfs.CacheRelease())
+	require.Contains(t, buf.String(), `msg="cached asset" asset=test.txt size=9`)
+
+	_, err = fs.ReadFile(rfs, "test.txt")
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), `msg="cache hit" asset=test.txt size=9`)
+}
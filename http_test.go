@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPFileServer(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{
+		"asset.txt":  []byte("0123456789"),
+		"notes.json": []byte(`{"notes":true}`),
+	})
+	srv := httptest.NewServer(http.FileServer(http.FS(rfs)))
+	t.Cleanup(srv.Close)
+
+	get := func(t *testing.T, path, rangeHeader string) (*http.Response, string) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+		if rangeHeader != "" {
+			req.Header.Set("Range", rangeHeader)
+		}
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	t.Run("range", func(t *testing.T) {
+		t.Parallel()
+		resp, body := get(t, "/asset.txt", "bytes=2-5")
+		require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+		require.Equal(t, "2345", body)
+		require.Equal(t, "bytes 2-5/10", resp.Header.Get("Content-Range"))
+	})
+
+	t.Run("full", func(t *testing.T) {
+		t.Parallel()
+		resp, body := get(t, "/notes.json", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, `{"notes":true}`, body)
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	})
+
+	t.Run("listing", func(t *testing.T) {
+		t.Parallel()
+		resp, body := get(t, "/", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, body, `<a href="asset.txt">asset.txt</a>`)
+		require.Contains(t, body, `<a href="notes.json">notes.json</a>`)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+		resp, _ := get(t, "/missing.txt", "")
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("index", func(t *testing.T) {
+		t.Parallel()
+		_, err := rfs.Open("index.html")
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+var (
+	_ fs.StatFS    = (*isolatedFS)(nil)
+	_ fs.ReadDirFS = (*isolatedFS)(nil)
+)
+
+// IsolateAsset returns a filesystem whose only entry is the named file,
+// found at its root under its base name. This scopes the filesystem passed
+// to libraries that expect a single known file. The file is opened through
+// the release filesystem, so it is read from the caches when available and
+// verified and decompressed according to the options.
+func (rfs *ReleaseFileSystem) IsolateAsset(name string) (fs.FS, error) {
+	info, err := rfs.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, &fs.PathError{Op: "isolate", Path: name, Err: fmt.Errorf("%q is a directory", name)}
+	}
+	return &isolatedFS{rfs: rfs, path: name, name: path.Base(name)}, nil
+}
+
+// isolatedFS exposes a single file of a release filesystem at its root.
+type isolatedFS struct {
+	rfs  *ReleaseFileSystem
+	path string // Path of the file in the release filesystem
+	name string // Name of the file in the isolated filesystem
+}
+
+func (ifs *isolatedFS) Open(name string) (fs.File, error) {
+	switch {
+	case !fs.ValidPath(name):
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	case name == ".":
+		entries, err := ifs.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+		return &ReleaseDir{
+			Tag:        ifs.rfs.Release.Tag,
+			Ctime:      ifs.rfs.Release.modTime(),
+			Mtime:      ifs.rfs.Release.modTime(),
+			AssetFiles: entries,
+		}, nil
+	case name == ifs.name:
+		return ifs.rfs.Open(ifs.path)
+	default:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+}
+
+func (ifs *isolatedFS) Stat(name string) (fs.FileInfo, error) {
+	switch {
+	case !fs.ValidPath(name):
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	case name == ".":
+		return ifs.rfs.Stat(name)
+	case name == ifs.name:
+		return ifs.rfs.Stat(ifs.path)
+	default:
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+}
+
+// ReadDir lists the root directory, the isolated file is its only entry.
+func (ifs *isolatedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name != "." {
+		if _, err := ifs.Stat(name); err != nil {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+		}
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	info, err := ifs.rfs.Stat(ifs.path)
+	if err != nil {
+		return nil, err
+	}
+	return []fs.DirEntry{fs.FileInfoToDirEntry(info)}, nil
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsolateAsset(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{
+		"bin--linux--tool": []byte("tool"),
+		"other.txt":        []byte("other"),
+	})
+	rfs.Options.NameRewriter = func(s string) string { return strings.ReplaceAll(s, "--", "/") }
+	rfs.indexAssets()
+
+	for _, tc := range []struct {
+		name    string
+		path    string
+		expect  string
+		mustErr bool
+	}{
+		{"root", "other.txt", "other.txt", false},
+		{"nested", "bin/linux/tool", "tool", false},
+		{"directory", "bin", "", true},
+		{"missing", "missing.txt", "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ifs, err := rfs.IsolateAsset(tc.path)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, fstest.TestFS(ifs, tc.expect))
+
+			entries, err := fs.ReadDir(ifs, ".")
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			require.Equal(t, tc.expect, entries[0].Name())
+
+			_, err = ifs.Open("missing.txt")
+			require.ErrorIs(t, err, fs.ErrNotExist)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"io/fs"
+	"iter"
+	"path"
+)
+
+// Assets returns an iterator over the assets in the filesystem, keyed by
+// their path. It only walks the release metadata, no data is downloaded.
+// Hidden and shadowed duplicate assets are not included. If the release
+// cannot be loaded, the iterator yields nothing.
+func (rfs *ReleaseFileSystem) Assets() iter.Seq2[string, *AssetFile] {
+	return func(yield func(string, *AssetFile) bool) {
+		if err := rfs.ensureLoaded(); err != nil {
+			return
+		}
+		for i, a := range rfs.Release.Assets {
+			if j, ok := rfs.Release.pathIndex[a.Path()]; !ok || j != i {
+				continue
+			}
+			if !yield(a.Path(), a) {
+				return
+			}
+		}
+	}
+}
+
+// OpenAll returns an iterator that opens the assets in the filesystem one
+// at a time as the loop advances, using ctx for the requests. Each file is
+// closed when the loop moves on to the next one or stops, so files must
+// not be used outside of the loop body.
+//
+// If an asset fails to open, the file yielded returns the error from all
+// its methods except Close.
+func (rfs *ReleaseFileSystem) OpenAll(ctx context.Context) iter.Seq2[string, fs.File] {
+	return func(yield func(string, fs.File) bool) {
+		for name := range rfs.Assets() {
+			var f fs.File
+			af, err := rfs.openFile(ctx, name)
+			if err != nil {
+				f = &failedFile{err: &fs.PathError{Op: "open", Path: name, Err: err}}
+			} else {
+				af.IName = path.Base(af.Path())
+				f = af.seekable()
+			}
+
+			more := yield(name, f)
+			f.Close() //nolint:errcheck,gosec
+			if !more {
+				return
+			}
+		}
+	}
+}
+
+// failedFile is yielded by OpenAll in place of a file that failed to open.
+type failedFile struct {
+	err error
+}
+
+func (ff *failedFile) Stat() (fs.FileInfo, error) { return nil, ff.err }
+func (ff *failedFile) Read([]byte) (int, error)   { return 0, ff.err }
+func (ff *failedFile) Close() error               { return nil }
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssets(t *testing.T) {
+	t.Parallel()
+	rfs, caller := newRemoteTestFS(t, map[string][]byte{"a.txt": []byte("a"), "b.txt": []byte("bb")})
+
+	sizes := map[string]int64{}
+	for name, a := range rfs.Assets() {
+		sizes[name] = a.Size()
+	}
+	require.Equal(t, map[string]int64{"a.txt": 1, "b.txt": 2}, sizes)
+	require.Empty(t, caller.requests)
+
+	// Breaking out of the loop stops the iteration
+	n := 0
+	for range rfs.Assets() {
+		n++
+		break
+	}
+	require.Equal(t, 1, n)
+}
+
+func TestOpenAll(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{"a.txt": []byte("a"), "b.txt": []byte("bb"), "gone.txt": []byte("gone")}
+	rfs, caller := newRemoteTestFS(t, files)
+	delete(caller.responses, "https://github.com/example/repo/releases/download/v1.0.0/gone.txt")
+
+	opened := []fs.File{}
+	for name, f := range rfs.OpenAll(context.Background()) {
+		opened = append(opened, f)
+		data, err := io.ReadAll(f)
+		if name == "gone.txt" {
+			require.Error(t, err)
+			_, err := f.Stat()
+			var perr *fs.PathError
+			require.ErrorAs(t, err, &perr)
+			require.Equal(t, "gone.txt", perr.Path)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, files[name], data)
+		info, err := f.Stat()
+		require.NoError(t, err)
+		require.Equal(t, name, info.Name())
+	}
+	require.Len(t, opened, 3)
+
+	// Files are closed once the loop moves on
+	for _, f := range opened {
+		if _, err := f.Stat(); err == nil {
+			_, err := f.Read(make([]byte, 1))
+			require.ErrorIs(t, err, fs.ErrClosed)
+		}
+	}
+
+	// Stopping early only opens the files yielded
+	caller.requests = nil
+	for range rfs.OpenAll(context.Background()) {
+		break
+	}
+	require.Len(t, caller.requests, 1)
+}
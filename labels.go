@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io/fs"
+	"strings"
+)
+
+// indexLabels builds the index of asset labels when they are enabled as
+// alternative names. It must run after the asset paths are indexed as
+// labels never shadow an asset or directory path.
+func (rfs *ReleaseFileSystem) indexLabels() {
+	rfs.Release.labelIndex = nil
+	if !rfs.Options.LabelAsName {
+		return
+	}
+
+	rfs.Release.labelIndex = map[string]int{}
+	shared := map[string]struct{}{}
+	for _, i := range rfs.Release.pathIndex {
+		a := rfs.Release.Assets[i]
+		label := labelPath(a.Label)
+		if label == "" || label == a.Path() {
+			continue
+		}
+
+		_, isPath := rfs.Release.pathIndex[label]
+		_, isDir := rfs.Release.dirIndex[label]
+		if isPath || isDir {
+			rfs.logger().Warn("asset label collides with an asset path, ignoring it", "asset", a.Name(), "label", label)
+			continue
+		}
+
+		if j, ok := rfs.Release.labelIndex[label]; ok && j != i {
+			shared[label] = struct{}{}
+			continue
+		}
+		rfs.Release.labelIndex[label] = i
+	}
+
+	for label := range shared {
+		rfs.logger().Warn("asset label is shared by more than one asset, ignoring it", "label", label)
+		delete(rfs.Release.labelIndex, label)
+	}
+}
+
+// labelPath sanitizes an asset label into a valid path. Path separators
+// are replaced and surrounding space is trimmed. It returns an empty string
+// if the label cannot be used as a path.
+func labelPath(label string) string {
+	p := strings.TrimSpace(strings.NewReplacer("/", "-", "\\", "-").Replace(label))
+	if p == "." || p == ".." || !fs.ValidPath(p) {
+		return ""
+	}
+	return p
+}
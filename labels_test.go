@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelAsName(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{
+		"myapp-linux-amd64": []byte("linux"),
+		"myapp-darwin":      []byte("darwin"),
+		"notes.txt":         []byte("notes"),
+		"a.txt":             []byte("a"),
+		"b.txt":             []byte("b"),
+	}
+	labels := map[string]string{
+		"myapp-linux-amd64": "MyApp (Linux x64)",
+		"myapp-darwin":      "MyApp / macOS",
+		"notes.txt":         "a.txt", // collides with an asset name
+		"a.txt":             "Shared",
+		"b.txt":             "Shared",
+	}
+
+	for _, tc := range []struct {
+		name      string
+		enabled   bool
+		path      string
+		expect    string
+		expectErr error
+	}{
+		{"disabled", false, "MyApp (Linux x64)", "", fs.ErrNotExist},
+		{"label", true, "MyApp (Linux x64)", "linux", nil},
+		{"sanitized", true, "MyApp - macOS", "darwin", nil},
+		{"name", true, "myapp-darwin", "darwin", nil},
+		{"name-wins", true, "a.txt", "a", nil},
+		{"shared", true, "Shared", "", fs.ErrNotExist},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := newCachedTestFS(t, files)
+			rfs.Options.LabelAsName = tc.enabled
+			for _, a := range rfs.Release.Assets {
+				a.Label = labels[a.Name()]
+			}
+			rfs.indexAssets()
+
+			data, err := fs.ReadFile(rfs, tc.path)
+			if tc.expectErr != nil {
+				require.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, string(data))
+
+			// Labels are not listed
+			entries, err := rfs.ReadDir(".")
+			require.NoError(t, err)
+			require.Len(t, entries, len(files))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import "fmt"
+
+// ensureLoaded loads the release on first access when the filesystem was
+// created with WithLazyLoad. Concurrent callers wait for a single load. If
+// loading fails, the error is returned and the next call tries again.
+func (rfs *ReleaseFileSystem) ensureLoaded() error {
+	if !rfs.Options.LazyLoad || rfs.loaded.Load() {
+		return nil
+	}
+
+	rfs.loadMtx.Lock()
+	defer rfs.loadMtx.Unlock()
+
+	// Another caller may have loaded the release while we waited
+	if rfs.loaded.Load() {
+		return nil
+	}
+
+	if err := rfs.LoadRelease(); err != nil {
+		return fmt.Errorf("loading release: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io/fs"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyLoad(t *testing.T) {
+	t.Parallel()
+	const endpoint = "repos/example/repo/releases/tags/v1.0.0"
+	const release = `{"id": 42, "tag_name": "v1.0.0", "assets": [{"id": 1, "name": "notes.txt", "size": 5}]}`
+
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+		rfs, err := New(
+			WithOrganization("example"), WithRepository("repo"), WithTag("v1.0.0"), WithLazyLoad(true),
+		)
+		require.NoError(t, err)
+		require.False(t, rfs.loaded.Load())
+
+		caller := &fakeCaller{
+			delay:     20 * time.Millisecond,
+			responses: map[string]fakeResponse{endpoint: {http.StatusOK, release}},
+		}
+		rfs.client = caller
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				info, err := rfs.Stat("notes.txt")
+				require.NoError(t, err)
+				require.Equal(t, int64(5), info.Size())
+			})
+		}
+		wg.Wait()
+		require.Equal(t, []string{endpoint}, caller.requests)
+		require.Equal(t, "v1.0.0", rfs.ResolvedTag())
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		t.Parallel()
+		rfs, err := New(
+			WithOrganization("example"), WithRepository("repo"), WithTag("v1.0.0"), WithLazyLoad(true),
+		)
+		require.NoError(t, err)
+
+		caller := &fakeCaller{responses: map[string]fakeResponse{
+			endpoint: {http.StatusServiceUnavailable, `{"message":"unavailable"}`},
+		}}
+		rfs.client = caller
+
+		_, err = fs.ReadDir(rfs, ".")
+		require.Error(t, err)
+
+		// The failure is not cached, the next access loads the release
+		caller.responses[endpoint] = fakeResponse{http.StatusOK, release}
+		entries, err := fs.ReadDir(rfs, ".")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Len(t, caller.requests, 2)
+	})
+	t.Run("accessors", func(t *testing.T) {
+		t.Parallel()
+		for name, check := range map[string]func(t *testing.T, rfs *ReleaseFileSystem){
+			"AssetCount": func(t *testing.T, rfs *ReleaseFileSystem) {
+				t.Helper()
+				require.Equal(t, 1, rfs.AssetCount())
+			},
+			"AssetNames": func(t *testing.T, rfs *ReleaseFileSystem) {
+				t.Helper()
+				require.Equal(t, []string{"notes.txt"}, rfs.AssetNames())
+			},
+			"TotalSize": func(t *testing.T, rfs *ReleaseFileSystem) {
+				t.Helper()
+				require.Equal(t, int64(5), rfs.TotalSize())
+			},
+			"AssetURL": func(t *testing.T, rfs *ReleaseFileSystem) {
+				t.Helper()
+				_, err := rfs.AssetURL("notes.txt")
+				require.NoError(t, err)
+			},
+			"Plan": func(t *testing.T, rfs *ReleaseFileSystem) {
+				t.Helper()
+				plan, err := rfs.Plan()
+				require.NoError(t, err)
+				require.Len(t, plan, 1)
+			},
+			"ReleaseInfo": func(t *testing.T, rfs *ReleaseFileSystem) {
+				t.Helper()
+				require.Equal(t, "v1.0.0", rfs.ReleaseInfo().Tag)
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				rfs, err := New(
+					WithOrganization("example"), WithRepository("repo"), WithTag("v1.0.0"), WithLazyLoad(true),
+				)
+				require.NoError(t, err)
+				caller := &fakeCaller{responses: map[string]fakeResponse{endpoint: {http.StatusOK, release}}}
+				rfs.client = caller
+
+				check(t, rfs)
+				require.Equal(t, []string{endpoint}, caller.requests)
+			})
+		}
+	})
+}
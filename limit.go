@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// openSlots returns the semaphore that limits the remote files open at
+// the same time or nil if they are not limited.
+func (rfs *ReleaseFileSystem) openSlots() chan struct{} {
+	if rfs.Options.MaxConcurrentOpens <= 0 {
+		return nil
+	}
+	rfs.openSlotsOnce.Do(func() {
+		rfs.openSlotsCh = make(chan struct{}, rfs.Options.MaxConcurrentOpens)
+	})
+	return rfs.openSlotsCh
+}
+
+// acquireOpen waits for a slot to open a remote file. It returns the
+// function that releases the slot, which is safe to call more than once.
+func (rfs *ReleaseFileSystem) acquireOpen(ctx context.Context) (func(), error) {
+	slots := rfs.openSlots()
+	if slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+		var once sync.Once
+		return func() { once.Do(func() { <-slots }) }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// releaseOnClose releases the slot of a remote file when it is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (rc *releaseOnClose) Close() error {
+	defer rc.release()
+	return rc.ReadCloser.Close()
+}
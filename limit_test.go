@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxConcurrentOpens(t *testing.T) {
+	t.Parallel()
+	rfs, caller := newRemoteTestFS(t, map[string][]byte{
+		"a.txt":    []byte("a"),
+		"b.txt":    []byte("b"),
+		"gone.txt": []byte("gone"),
+	})
+	delete(caller.responses, rfs.Release.Assets[rfs.Release.fileIndex["gone.txt"]].URL)
+	require.NoError(t, WithMaxConcurrentOpens(1)(&rfs.Options))
+
+	f1, err := rfs.OpenRemoteFile("a.txt")
+	require.NoError(t, err)
+
+	// The second open waits until the first file is closed
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = rfs.OpenRemoteFileContext(ctx, "b.txt")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	opened := make(chan error)
+	go func() {
+		f2, err := rfs.OpenRemoteFile("b.txt")
+		if err == nil {
+			err = f2.Close()
+		}
+		opened <- err
+	}()
+
+	select {
+	case <-opened:
+		t.Fatal("file opened while the limit was reached")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	require.NoError(t, f1.Close())
+	require.NoError(t, <-opened)
+
+	// Failed opens release their slot
+	_, err = rfs.OpenRemoteFile("gone.txt")
+	require.Error(t, err)
+	f, err := rfs.OpenRemoteFile("a.txt")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.Error(t, WithMaxConcurrentOpens(-1)(&Options{}))
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// OpenMatch opens the single asset whose name matches the glob pattern,
+// using the syntax of path.Match. This is handy to pick the artifact for
+// a platform from a release, for example "tool-*-linux-amd64.tar.gz".
+// If no asset matches, the error wraps fs.ErrNotExist. If more than one
+// asset matches, the error wraps ErrAmbiguousName and lists the matches.
+func (rfs *ReleaseFileSystem) OpenMatch(pattern string) (fs.File, error) {
+	matches, err := fs.Glob(rfs, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("matching %q: %w", pattern, err)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &fs.PathError{Op: "open", Path: pattern, Err: fs.ErrNotExist}
+	case 1:
+		return rfs.Open(matches[0])
+	default:
+		return nil, fmt.Errorf(
+			"%w: %q matches %s", ErrAmbiguousName, pattern, strings.Join(matches, ", "),
+		)
+	}
+}
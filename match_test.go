@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenMatch(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{
+		"tool-v1.0.0-linux-amd64.tar.gz":  []byte("linux-amd64"),
+		"tool-v1.0.0-linux-arm64.tar.gz":  []byte("linux-arm64"),
+		"tool-v1.0.0-darwin-arm64.tar.gz": []byte("darwin-arm64"),
+	})
+
+	for _, tc := range []struct {
+		name    string
+		pattern string
+		expect  string
+		errIs   error
+	}{
+		{"single", "tool-*-linux-amd64.tar.gz", "linux-amd64", nil},
+		{"exact", "tool-v1.0.0-darwin-arm64.tar.gz", "darwin-arm64", nil},
+		{"none", "tool-*-windows-amd64.zip", "", fs.ErrNotExist},
+		{"multiple", "tool-*-linux-*.tar.gz", "", ErrAmbiguousName},
+		{"bad-pattern", "tool-[", "", path.ErrBadPattern},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := rfs.OpenMatch(tc.pattern)
+			if tc.errIs != nil {
+				require.ErrorIs(t, err, tc.errIs)
+				return
+			}
+			require.NoError(t, err)
+			defer f.Close() //nolint:errcheck
+			data, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, string(data))
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// memoryCache holds asset data in memory up to a maximum number of bytes,
+// evicting the least recently used entries when the budget is exceeded.
+type memoryCache struct {
+	mtx      sync.Mutex
+	maxBytes int64
+	size     int64
+	entries  map[string]*list.Element
+	lru      *list.List
+}
+
+// memoryCacheEntry is the data of a cached asset
+type memoryCacheEntry struct {
+	name string
+	data []byte
+}
+
+func newMemoryCache(maxBytes int64) *memoryCache {
+	return &memoryCache{
+		maxBytes: maxBytes,
+		entries:  map[string]*list.Element{},
+		lru:      list.New(),
+	}
+}
+
+// get returns the cached data of an asset and marks it as recently used.
+func (mc *memoryCache) get(name string) ([]byte, bool) {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+
+	e, ok := mc.entries[name]
+	if !ok {
+		return nil, false
+	}
+	mc.lru.MoveToFront(e)
+	return e.Value.(*memoryCacheEntry).data, true //nolint:forcetypeassert
+}
+
+// put stores the data of an asset, evicting the least recently used assets
+// until it fits in the budget. Data larger than the whole budget is not
+// stored.
+func (mc *memoryCache) put(name string, data []byte) {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+
+	if int64(len(data)) > mc.maxBytes {
+		return
+	}
+
+	if e, ok := mc.entries[name]; ok {
+		mc.remove(e)
+	}
+
+	for mc.size+int64(len(data)) > mc.maxBytes {
+		mc.remove(mc.lru.Back())
+	}
+
+	mc.entries[name] = mc.lru.PushFront(&memoryCacheEntry{name: name, data: data})
+	mc.size += int64(len(data))
+}
+
+// delete drops an asset from the cache if it is stored.
+func (mc *memoryCache) delete(name string) {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+
+	if e, ok := mc.entries[name]; ok {
+		mc.remove(e)
+	}
+}
+
+// remove drops an entry from the cache. Must be called with the lock held.
+func (mc *memoryCache) remove(e *list.Element) {
+	entry := mc.lru.Remove(e).(*memoryCacheEntry) //nolint:forcetypeassert
+	delete(mc.entries, entry.name)
+	mc.size -= int64(len(entry.data))
+}
+
+// memoryCache returns the in-memory cache or nil if it is not enabled.
+func (rfs *ReleaseFileSystem) memoryCache() *memoryCache {
+	if rfs.Options.MemoryCacheSize <= 0 {
+		return nil
+	}
+	rfs.memCacheOnce.Do(func() {
+		rfs.memCache = newMemoryCache(rfs.Options.MemoryCacheSize)
+	})
+	return rfs.memCache
+}
+
+// openMemoryCachedFile serves an asset from the memory cache. On a miss,
+// the asset is fetched and, if it fits in the cache budget, read fully into
+// memory to serve subsequent opens.
+func (rfs *ReleaseFileSystem) openMemoryCachedFile(ctx context.Context, name string) (*AssetFile, error) {
+	mc := rfs.memoryCache()
+	if data, ok := mc.get(name); ok {
+		return rfs.newMemoryFile(name, data, SourceMemory)
+	}
+
+	f, err := rfs.openStoredFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assets too large for the cache are streamed directly
+	if f.Size() > mc.maxBytes {
+		return f, nil
+	}
+
+	// Archives and some other assets have an unknown size (-1), never
+	// read more than the budget. If the data turns out to be larger, the
+	// part already read is served followed by the rest of the stream.
+	data, err := io.ReadAll(io.LimitReader(f, mc.maxBytes+1))
+	if err != nil {
+		f.Close() //nolint:errcheck,gosec
+		return nil, fmt.Errorf("reading %q: %w", name, err)
+	}
+	if int64(len(data)) > mc.maxBytes {
+		f.DataStream = &readCloser{
+			Reader: io.MultiReader(bytes.NewReader(data), f.DataStream),
+			Closer: f.DataStream,
+		}
+		return f, nil
+	}
+	f.Close() //nolint:errcheck,gosec
+
+	// The first read reports where the data was fetched from
+	mc.put(name, data)
+	return rfs.newMemoryFile(name, data, f.Source())
+}
+
+// newMemoryFile returns a new asset file handle reading from data.
+func (rfs *ReleaseFileSystem) newMemoryFile(name string, data []byte, source Source) (*AssetFile, error) {
+	i, ok := rfs.Release.fileIndex[name]
+	if !ok {
+		return nil, fmt.Errorf("asset %q not found in index", name)
+	}
+	return &AssetFile{
+		DataStream:  newMemoryStream(data),
+		source:      source,
+		FileInfo:    rfs.Release.Assets[i].FileInfo,
+		URL:         rfs.Release.Assets[i].URL,
+		APIURL:      rfs.Release.Assets[i].APIURL,
+		DisplayPath: rfs.Release.Assets[i].DisplayPath,
+		ID:          rfs.Release.Assets[i].ID,
+		NodeID:      rfs.Release.Assets[i].NodeID,
+	}, nil
+}
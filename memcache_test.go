@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCacheEviction(t *testing.T) {
+	t.Parallel()
+	mc := newMemoryCache(10)
+	mc.put("a", []byte("aaaa"))
+	mc.put("b", []byte("bbbb"))
+
+	// Touch a so that b becomes the least recently used
+	_, ok := mc.get("a")
+	require.True(t, ok)
+
+	mc.put("c", []byte("cccc"))
+	_, ok = mc.get("b")
+	require.False(t, ok)
+	_, ok = mc.get("a")
+	require.True(t, ok)
+	_, ok = mc.get("c")
+	require.True(t, ok)
+	require.Equal(t, int64(8), mc.size)
+
+	// Data larger than the budget is never stored
+	mc.put("huge", []byte("0123456789abc"))
+	_, ok = mc.get("huge")
+	require.False(t, ok)
+}
+
+func TestOpenMemoryCache(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name        string
+		budget      int64
+		unknownSize bool
+		opens       []string
+		requests    int
+	}{
+		{"disabled", 0, false, []string{"a.txt", "a.txt"}, 2},
+		{"repeat-reads", 100, false, []string{"a.txt", "a.txt", "a.txt"}, 1},
+		{"eviction", 6, false, []string{"a.txt", "b.txt", "a.txt"}, 3},
+		{"too-large", 3, false, []string{"a.txt", "a.txt"}, 2},
+		{"unknown-size", 100, true, []string{"a.txt", "a.txt"}, 1},
+		{"unknown-size-too-large", 3, true, []string{"a.txt", "a.txt"}, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs, caller := newRemoteTestFS(t, map[string][]byte{
+				"a.txt": []byte("aaaa"), "b.txt": []byte("bbbb"),
+			})
+			rfs.Options.MemoryCacheSize = tc.budget
+			if tc.unknownSize {
+				for _, a := range rfs.Release.Assets {
+					a.ISize = -1
+				}
+			}
+
+			for _, name := range tc.opens {
+				f, err := rfs.Open(name)
+				require.NoError(t, err)
+				data, err := io.ReadAll(f)
+				require.NoError(t, err)
+				require.Len(t, data, 4)
+				require.NoError(t, f.Close())
+			}
+			require.Len(t, caller.requests, tc.requests)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"time"
+)
+
+var (
+	_ fs.FS        = (*mergedFS)(nil)
+	_ fs.StatFS    = (*mergedFS)(nil)
+	_ fs.ReadDirFS = (*mergedFS)(nil)
+)
+
+// mergedFS overlays several release filesystems in a single namespace.
+type mergedFS struct {
+	layers []*ReleaseFileSystem
+}
+
+// MergeFS returns a filesystem that presents the assets of several releases
+// in a single root directory. When more than one release has an asset with
+// the same name, later layers shadow earlier ones. Opening a file dispatches
+// to the release that owns it.
+func MergeFS(layers ...*ReleaseFileSystem) (fs.FS, error) {
+	if len(layers) == 0 {
+		return nil, errors.New("no release filesystems to merge")
+	}
+	for i, l := range layers {
+		if l == nil {
+			return nil, fmt.Errorf("release filesystem #%d is nil", i)
+		}
+	}
+	return &mergedFS{layers: slices.Clone(layers)}, nil
+}
+
+// owner returns the layer that serves name, searching from the top.
+func (mfs *mergedFS) owner(name string) (*ReleaseFileSystem, error) {
+	for _, l := range slices.Backward(mfs.layers) {
+		if _, err := l.Stat(name); err == nil {
+			return l, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, fs.ErrNotExist
+}
+
+// dirEntries returns the merged listing of a directory, sorted by name. The
+// directory is listed in every layer where it exists.
+func (mfs *mergedFS) dirEntries(dir string) ([]fs.DirEntry, error) {
+	entries := map[string]fs.DirEntry{}
+	found := false
+	for _, l := range mfs.layers {
+		layerEntries, err := l.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, e := range layerEntries {
+			entries[e.Name()] = e
+		}
+	}
+	if !found {
+		return nil, fs.ErrNotExist
+	}
+
+	ret := make([]fs.DirEntry, 0, len(entries))
+	for _, e := range entries {
+		ret = append(ret, e)
+	}
+	slices.SortFunc(ret, compareEntries)
+	return ret, nil
+}
+
+// isDir reports whether name is a directory in the merged namespace, that is,
+// the root or a directory in the layer that owns the path.
+func (mfs *mergedFS) isDir(name string) (bool, error) {
+	if name == "." {
+		return true, nil
+	}
+	l, err := mfs.owner(name)
+	if err != nil {
+		return false, err
+	}
+	info, err := l.Stat(name)
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// mtime returns the newest modification time of the merged releases.
+func (mfs *mergedFS) mtime() (time.Time, error) {
+	var t time.Time
+	for _, l := range mfs.layers {
+		if err := l.ensureLoaded(); err != nil {
+			return time.Time{}, err
+		}
+		if mt := l.Release.modTime(); mt.After(t) {
+			t = mt
+		}
+	}
+	return t, nil
+}
+
+func (mfs *mergedFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	isDir, err := mfs.isDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if !isDir {
+		l, err := mfs.owner(name)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		return l.Open(name)
+	}
+
+	entries, err := mfs.dirEntries(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	mtime, err := mfs.mtime()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &ReleaseDir{
+		Tag:        path.Base(name),
+		Ctime:      mtime,
+		Mtime:      mtime,
+		AssetFiles: entries,
+	}, nil
+}
+
+func (mfs *mergedFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if name == "." {
+		mtime, err := mfs.mtime()
+		if err != nil {
+			return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+		}
+		return FileInfo{IName: ".", Ctime: mtime, Mtime: mtime, IIsDir: true}, nil
+	}
+
+	l, err := mfs.owner(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return l.Stat(name)
+}
+
+func (mfs *mergedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	isDir, err := mfs.isDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	if !isDir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	entries, err := mfs.dirEntries(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	return entries, nil
+}
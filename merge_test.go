@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io/fs"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeFS(t *testing.T) {
+	t.Parallel()
+	core := newCachedTestFS(t, map[string][]byte{
+		"core.tar.gz": []byte("core"),
+		"README.md":   []byte("core readme"),
+	})
+	plugins := newCachedTestFS(t, map[string][]byte{
+		"plugins.tar.gz": []byte("plugins"),
+		"README.md":      []byte("plugins readme"),
+	})
+
+	mfs, err := MergeFS(core, plugins)
+	require.NoError(t, err)
+	require.NoError(t, fstest.TestFS(mfs, "core.tar.gz", "plugins.tar.gz", "README.md"))
+
+	entries, err := fs.ReadDir(mfs, ".")
+	require.NoError(t, err)
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	require.Equal(t, []string{"README.md", "core.tar.gz", "plugins.tar.gz"}, names)
+
+	// Later layers shadow earlier ones
+	for name, expect := range map[string]string{
+		"core.tar.gz":    "core",
+		"plugins.tar.gz": "plugins",
+		"README.md":      "plugins readme",
+	} {
+		data, err := fs.ReadFile(mfs, name)
+		require.NoError(t, err)
+		require.Equal(t, expect, string(data))
+	}
+
+	_, err = mfs.Open("missing.txt")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = MergeFS()
+	require.Error(t, err)
+	_, err = MergeFS(core, nil)
+	require.Error(t, err)
+}
+
+func TestMergeFSDirectories(t *testing.T) {
+	t.Parallel()
+	rewriter := func(name string) string {
+		return strings.ReplaceAll(name, "--", "/")
+	}
+	linux := newCachedTestFS(t, map[string][]byte{
+		"bin--linux--tool": []byte("linux"),
+		"docs--guide.md":   []byte("guide"),
+	})
+	linux.Options.NameRewriter = rewriter
+	linux.indexAssets()
+	darwin := newCachedTestFS(t, map[string][]byte{
+		"bin--darwin--tool": []byte("darwin"),
+	})
+	darwin.Options.NameRewriter = rewriter
+	darwin.indexAssets()
+
+	mfs, err := MergeFS(linux, darwin)
+	require.NoError(t, err)
+	require.NoError(t, fstest.TestFS(mfs, "bin/linux/tool", "bin/darwin/tool", "docs/guide.md"))
+
+	// Directories present in several layers list the assets of all of them
+	entries, err := fs.ReadDir(mfs, "bin")
+	require.NoError(t, err)
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	require.Equal(t, []string{"darwin", "linux"}, names)
+
+	walked := []string{}
+	require.NoError(t, fs.WalkDir(mfs, ".", func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			walked = append(walked, p)
+		}
+		return err
+	}))
+	require.Equal(t, []string{"bin/darwin/tool", "bin/linux/tool", "docs/guide.md"}, walked)
+
+	_, err = fs.ReadDir(mfs, "bin/linux/tool")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = fs.ReadDir(mfs, "missing")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestMergeFSLazyLoad(t *testing.T) {
+	t.Parallel()
+	const endpoint = "repos/example/repo/releases/tags/v1.0.0"
+	rfs, err := New(
+		WithOrganization("example"), WithRepository("repo"), WithTag("v1.0.0"), WithLazyLoad(true),
+	)
+	require.NoError(t, err)
+	rfs.client = &fakeCaller{responses: map[string]fakeResponse{endpoint: {
+		http.StatusOK,
+		`{"id": 42, "tag_name": "v1.0.0", "published_at": "2025-10-01T10:00:00Z", "assets": []}`,
+	}}}
+
+	mfs, err := MergeFS(rfs)
+	require.NoError(t, err)
+	info, err := fs.Stat(mfs, ".")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC), info.ModTime().UTC())
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// defaultMetadataFileName is the name of the virtual file exposing the
+// release data when WithExposeMetadataFile is set.
+const defaultMetadataFileName = ".release.json"
+
+// isMetadataFile returns true if name is the virtual release data file
+// and the options are set to expose it.
+func (rfs *ReleaseFileSystem) isMetadataFile(name string) bool {
+	return rfs.Options.ExposeMetadataFile && rfs.Options.MetadataFileName != "" &&
+		name == rfs.Options.MetadataFileName
+}
+
+// metadataFile returns a new in-memory file that serves the release
+// data marshaled as JSON.
+func (rfs *ReleaseFileSystem) metadataFile() (*AssetFile, error) {
+	data, err := json.Marshal(rfs.Release) //nolint:musttag
+	if err != nil {
+		return nil, fmt.Errorf("marshaling release data: %w", err)
+	}
+
+	return &AssetFile{
+		DataStream: newMemoryStream(data),
+		source:     SourceMemory,
+		FileInfo: FileInfo{
+			IName:        rfs.Options.MetadataFileName,
+			IContentType: "application/json",
+			ISize:        int64(len(data)),
+			Ctime:        rfs.Release.CreatedAt,
+			Mtime:        rfs.Release.modTime(),
+		},
+	}, nil
+}
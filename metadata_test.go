@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"encoding/json"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataFile(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		expose   bool
+		fileName string
+	}{
+		{"hidden", false, ""},
+		{"exposed", true, ""},
+		{"custom-name", true, "release-info.json"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := newCachedTestFS(t, map[string][]byte{"test.txt": []byte("test")})
+			rfs.Release.Tag = "v1.0.0"
+			rfs.Release.Name = "My Release"
+			rfs.Options.ExposeMetadataFile = tc.expose
+			if tc.fileName != "" {
+				rfs.Options.MetadataFileName = tc.fileName
+			}
+			name := rfs.Options.MetadataFileName
+
+			entries, err := fs.ReadDir(rfs, ".")
+			require.NoError(t, err)
+
+			f, err := rfs.Open(name)
+			if !tc.expose {
+				require.Len(t, entries, 1)
+				require.ErrorIs(t, err, fs.ErrNotExist)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+
+			info, err := f.Stat()
+			require.NoError(t, err)
+			require.Equal(t, name, info.Name())
+
+			data, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.Len(t, data, int(info.Size()))
+
+			release := ReleaseData{}
+			require.NoError(t, json.Unmarshal(data, &release)) //nolint:musttag
+			require.Equal(t, "v1.0.0", release.Tag)
+			require.Equal(t, "My Release", release.Name)
+			require.Len(t, release.Assets, 1)
+
+			statInfo, err := rfs.Stat(name)
+			require.NoError(t, err)
+			require.Equal(t, info.Size(), statInfo.Size())
+		})
+	}
+}
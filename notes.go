@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const generateNotesURLMask = `repos/%s/%s/releases/generate-notes`
+
+// GenerateReleaseNotes asks GitHub to generate the release notes for the
+// tag of the loaded release and returns them as markdown. The notes are
+// generated from the pull requests merged since the previous release, even
+// when the release body is empty. GitHub only generates notes for callers
+// with write access to the repository, so a token is required.
+func (rfs *ReleaseFileSystem) GenerateReleaseNotes(ctx context.Context) (string, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return "", err
+	}
+	tag := rfs.ResolvedTag()
+	if tag == "" {
+		return "", errors.New("unable to generate release notes, release tag not known")
+	}
+
+	payload, err := json.Marshal(map[string]string{"tag_name": tag})
+	if err != nil {
+		return "", fmt.Errorf("encoding request: %w", err)
+	}
+
+	ctx, cancel := withTimeout(ctx, rfs.Options.Timeout)
+	defer cancel()
+
+	resp, err := rfs.client.Call(ctx, http.MethodPost, fmt.Sprintf(
+		generateNotesURLMask, rfs.Options.Organization, rfs.Options.Repository,
+	), bytes.NewReader(payload))
+	if resp != nil {
+		defer resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode > 399 || resp.StatusCode < 200 {
+			return "", fmt.Errorf(
+				"HTTP error %d when generating release notes for %s: %s",
+				resp.StatusCode, rfs.releaseRef(), errorMessage(resp, err),
+			)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("generating release notes for %s: %w", rfs.releaseRef(), err)
+	}
+
+	notes := struct {
+		Body string `json:"body"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&notes); err != nil {
+		return "", fmt.Errorf("decoding release notes: %w", err)
+	}
+	return notes.Body, nil
+}
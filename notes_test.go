@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingCaller wraps a fakeCaller recording the method and body of
+// the last request.
+type recordingCaller struct {
+	*fakeCaller
+	method string
+	body   string
+}
+
+func (rc *recordingCaller) Call(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	rc.method = method
+	if body != nil {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		rc.body = string(data)
+	}
+	return rc.fakeCaller.Call(ctx, method, path, body)
+}
+
+func TestGenerateReleaseNotes(t *testing.T) {
+	t.Parallel()
+	const endpoint = "repos/example/repo/releases/generate-notes"
+	for _, tc := range []struct {
+		name     string
+		tag      string
+		response *fakeResponse
+		expect   string
+		mustErr  bool
+	}{
+		{
+			"ok", "v1.0.0",
+			&fakeResponse{http.StatusOK, `{"name": "v1.0.0", "body": "## What's Changed\n* Fix bug"}`},
+			"## What's Changed\n* Fix bug", false,
+		},
+		{"forbidden", "v1.0.0", &fakeResponse{http.StatusForbidden, `{"message": "Resource not accessible"}`}, "", true},
+		{"no-tag", "", nil, "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			caller := &recordingCaller{fakeCaller: &fakeCaller{responses: map[string]fakeResponse{}}}
+			if tc.response != nil {
+				caller.responses[endpoint] = *tc.response
+			}
+			rfs := &ReleaseFileSystem{Options: defaultOptions, client: caller}
+			rfs.Options.Organization = "example"
+			rfs.Options.Repository = "repo"
+			rfs.Release.Tag = tc.tag
+			rfs.loaded.Store(true)
+
+			notes, err := rfs.GenerateReleaseNotes(t.Context())
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, notes)
+			require.Equal(t, http.MethodPost, caller.method)
+			require.JSONEq(t, `{"tag_name": "v1.0.0"}`, caller.body)
+		})
+	}
+}
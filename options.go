@@ -4,31 +4,133 @@
 package ghrfs
 
 import (
+	"crypto/x509"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 type optFunc func(*Options) error
 
+// Download methods accepted by WithDownloadVia
+const (
+	DownloadViaBrowser = "browser"
+	DownloadViaAPI     = "api"
+)
+
+// Cache layouts accepted by WithCacheLayout
+const (
+	CacheLayoutFlat  = "flat"
+	CacheLayoutByTag = "byTag"
+)
+
 // Options is the configuration struct for the github FS
 type Options struct {
-	Cache             bool
-	ParallelDownloads int
-	Host              string
-	Organization      string
-	Repository        string
-	CachePath         string
-	CacheMaxSize      int64
-	CacheExtensions   []string
-	Tag               string
+	Cache                  bool
+	ParallelDownloads      int
+	Host                   string
+	Organization           string
+	Repository             string
+	CachePath              string
+	CacheMaxSize           int64
+	CacheExtensions        []string
+	Tag                    string
+	AssetFilter            func(*AssetFile) bool
+	AutoDecompress         bool
+	CompressedSuffixes     []string
+	LatestSemver           bool
+	LatestSemverPrerelease bool
+	StrictCache            bool
+	Mirror                 string
+	Timeout                time.Duration
+	DownloadTimeout        time.Duration
+	ReleaseID              int64
+	ExposeMetadataFile     bool
+	MetadataFileName       string
+	MemoryCacheSize        int64
+	CaseInsensitiveLookup  bool
+	Verifier               AssetVerifier
+	SignatureSuffixes      []string
+	UserAgent              string
+	APIVersion             string
+	CacheMetadataName      string
+	Token                  string
+	Anonymous              bool
+	MaxDownloadSize        int64
+	Logger                 *slog.Logger
+	ResumableCache         bool
+	HTTPClient             *http.Client
+	Proxy                  *url.URL
+	IncludeDrafts          bool
+	PreserveTimestamps     bool
+	CacheAssets            []string
+	SourceArchives         bool
+	NameRewriter           func(string) string
+	DeduplicateNames       bool
+	ReadDirPrefix          string
+	LazyLoad               bool
+	URLRewriter            func(string) string
+	DownloadVia            string
+	LabelAsName            bool
+	ExpandArchives         []string
+	IncludePrereleases     bool
+	StrictSize             bool
+	CacheLayout            string
+	FallbackToTagArchive   bool
+	MaxConcurrentOpens     int
+	FailOnMissingCache     bool
+	NameSanitizer          func(string) (string, error)
+	RequirePublished       bool
+	CacheBackend           Cache
+	TracerProvider         trace.TracerProvider
+	MaxBytesPerSecond      int64
+	Revalidate             bool
+	InsecureSkipVerify     bool
+	RootCAs                *x509.CertPool
+	BackgroundCache        bool
+	RetryAttempts          int
+	RetryBackoff           time.Duration
+	ResponseTap            func([]byte)
 }
 
 // Default options
 var defaultOptions = Options{
-	Host:              githubAPIURL,
-	Cache:             false,
-	ParallelDownloads: 3,
+	Host:               githubAPIURL,
+	Cache:              false,
+	ParallelDownloads:  3,
+	CompressedSuffixes: []string{".gz"},
+	MetadataFileName:   defaultMetadataFileName,
+	Verifier:           NoopVerifier{},
+	SignatureSuffixes:  defaultSignatureSuffixes,
+	CacheMetadataName:  releaseDataFile,
+	Logger:             discardLogger,
+	DownloadVia:        DownloadViaBrowser,
+	StrictSize:         true,
+	CacheLayout:        CacheLayoutFlat,
+}
+
+// Validate checks the options hold what is needed to load a release from
+// GitHub: the API host, the organization and the repository. The release is
+// selected by its ID or tag, an empty tag selects the latest release so
+// there is always one to load. The returned error wraps ErrInvalidOptions.
+func (opts *Options) Validate() error {
+	switch {
+	case opts.Host == "":
+		return fmt.Errorf("%w: host is required", ErrInvalidOptions)
+	case opts.Organization == "":
+		return fmt.Errorf("%w: organization is required", ErrInvalidOptions)
+	case opts.Repository == "":
+		return fmt.Errorf("%w: repository is required", ErrInvalidOptions)
+	case opts.ReleaseID < 0:
+		return fmt.Errorf("%w: invalid release ID %d", ErrInvalidOptions, opts.ReleaseID)
+	}
+	return nil
 }
 
 const releasePathPattern = `/([A-Za-z0-9-_\.]+)/([A-Za-z0-9-_\.]+)/releases/tag/(\S+)`
@@ -87,6 +189,20 @@ func WithRepository(repo string) optFunc {
 	}
 }
 
+// latestTag is the tag set by WithLatest to select the latest release
+const latestTag = "latest"
+
+// isLatestTag returns true if tag selects the latest release. An empty tag
+// deliberately means the latest release, so options that don't set a tag
+// get it by default. Setting the tag to "latest", as WithLatest does,
+// states it explicitly. A repository tag literally named "latest" can only
+// be loaded by its release ID.
+func isLatestTag(tag string) bool {
+	return tag == "" || tag == latestTag
+}
+
+// WithTag sets the tag of the release to load. An empty tag or "latest"
+// selects the latest release.
 func WithTag(tag string) optFunc {
 	return func(opts *Options) error {
 		opts.Tag = tag
@@ -94,6 +210,18 @@ func WithTag(tag string) optFunc {
 	}
 }
 
+// WithLatest selects the latest release of the repository, as GitHub
+// defines it, overriding any tag or release ID set before. Combine it with
+// WithLatestSemver or WithIncludePrereleases to change how the latest
+// release is chosen.
+func WithLatest() optFunc {
+	return func(opts *Options) error {
+		opts.Tag = latestTag
+		opts.ReleaseID = 0
+		return nil
+	}
+}
+
 func WithCache(useCache bool) optFunc {
 	return func(opts *Options) error {
 		opts.Cache = useCache
@@ -101,6 +229,21 @@ func WithCache(useCache bool) optFunc {
 	}
 }
 
+// WithBackgroundCache caches the release in the background instead of
+// blocking until all the assets are downloaded when the release is loaded.
+// Enabling it also enables the cache. Until an asset is cached, opening it
+// reads it from GitHub. Use WaitForCache to wait for the caching to finish
+// and get its error. Closing the filesystem cancels the caching.
+func WithBackgroundCache(background bool) optFunc {
+	return func(opts *Options) error {
+		opts.BackgroundCache = background
+		if background {
+			opts.Cache = true
+		}
+		return nil
+	}
+}
+
 func WithCachePath(path string) optFunc {
 	return func(opts *Options) error {
 		opts.CachePath = path
@@ -108,23 +251,700 @@ func WithCachePath(path string) optFunc {
 	}
 }
 
+// WithParallelDownloads sets how many assets are downloaded at a time when
+// caching, extracting or checksumming the release. Zero removes the limit.
 func WithParallelDownloads(dl int) optFunc {
 	return func(opts *Options) error {
+		if dl < 0 {
+			return fmt.Errorf("invalid number of parallel downloads %d", dl)
+		}
 		opts.ParallelDownloads = dl
 		return nil
 	}
 }
 
+// WithCacheMaxSize skips caching assets larger than size bytes. Zero
+// removes the limit.
 func WithCacheMaxSize(size int64) optFunc {
 	return func(opts *Options) error {
+		if size < 0 {
+			return fmt.Errorf("invalid cache maximum size %d", size)
+		}
 		opts.CacheMaxSize = size
 		return nil
 	}
 }
 
+// WithCacheExtensions limits caching to the assets whose file extension is
+// one of exts. Extensions are matched without regard to case and may be
+// written with or without the leading dot.
 func WithCacheExtensions(exts []string) optFunc {
 	return func(opts *Options) error {
-		opts.CacheExtensions = exts
+		normalized := make([]string, 0, len(exts))
+		for _, ext := range exts {
+			ext = strings.ToLower(strings.TrimLeft(strings.TrimSpace(ext), "."))
+			if ext == "" {
+				return fmt.Errorf("invalid empty cache extension")
+			}
+			normalized = append(normalized, ext)
+		}
+		opts.CacheExtensions = normalized
+		return nil
+	}
+}
+
+// WithAssetFilter sets a function that decides which assets are loaded from
+// the release. Assets for which the filter returns false are dropped when the
+// release is loaded: they are not listed by ReadDir, not cached and opening
+// or statting them returns fs.ErrNotExist.
+func WithAssetFilter(filter func(*AssetFile) bool) optFunc {
+	return func(opts *Options) error {
+		opts.AssetFilter = filter
+		return nil
+	}
+}
+
+// WithAutoDecompress makes Open transparently decompress gzipped assets. An
+// asset is considered compressed when its name ends with one of the suffixes
+// in Options.CompressedSuffixes (".gz" by default).
+func WithAutoDecompress(decompress bool) optFunc {
+	return func(opts *Options) error {
+		opts.AutoDecompress = decompress
+		return nil
+	}
+}
+
+// WithCompressedSuffixes overrides the list of filename suffixes that mark an
+// asset as gzip compressed when auto decompression is enabled.
+func WithCompressedSuffixes(suffixes []string) optFunc {
+	return func(opts *Options) error {
+		opts.CompressedSuffixes = suffixes
+		return nil
+	}
+}
+
+// WithLatestSemver resolves the latest release by listing the repository
+// releases and picking the one tagged with the highest semantic version
+// instead of using GitHub's latest release. Tags that are not valid semver
+// strings are ignored. Prereleases are considered if includePrerelease is
+// true. This only applies when the tag is empty or "latest".
+func WithLatestSemver(includePrerelease bool) optFunc {
+	return func(opts *Options) error {
+		opts.LatestSemver = true
+		opts.LatestSemverPrerelease = includePrerelease
+		return nil
+	}
+}
+
+// WithStrictCache makes opening a cached file fail when its size does not
+// match the size of the asset in the release. When not set, mismatched
+// cached files are ignored and the asset is fetched remotely.
+func WithStrictCache(strict bool) optFunc {
+	return func(opts *Options) error {
+		opts.StrictCache = strict
+		return nil
+	}
+}
+
+// WithMirror sets a URL template to download assets from a mirror. The
+// template is expanded replacing {org}, {repo}, {tag} and {name} with the
+// release and asset data, for example:
+//
+//	https://mirror.example.com/{org}/{repo}/{tag}/{name}
+//
+// Assets are requested from the mirror first, if it fails or returns an
+// HTTP error, the asset is downloaded from its original URL.
+func WithMirror(template string) optFunc {
+	return func(opts *Options) error {
+		opts.Mirror = template
+		return nil
+	}
+}
+
+// WithRetry makes up to attempts tries to fetch the release data and to
+// start each asset download when they fail with network errors, rate limits
+// or server errors. The wait between attempts starts at backoff and doubles
+// each time. Other errors are not retried, nor are downloads interrupted
+// after the data started flowing. When more than one attempt is made, the
+// error returned is a *RetryError. One attempt disables retrying.
+func WithRetry(attempts int, backoff time.Duration) optFunc {
+	return func(opts *Options) error {
+		if attempts < 1 {
+			return fmt.Errorf("invalid number of attempts %d", attempts)
+		}
+		if backoff < 0 {
+			return fmt.Errorf("invalid retry backoff %s", backoff)
+		}
+		opts.RetryAttempts = attempts
+		opts.RetryBackoff = backoff
+		return nil
+	}
+}
+
+// WithResponseTap sets a function that receives the raw JSON body of the
+// release data returned by the GitHub API before it is decoded, to debug
+// unexpected responses. It is called on every successful response, even if
+// decoding it fails. The function must not modify or keep the slice. When
+// no tap is set, the response is decoded without buffering it.
+func WithResponseTap(tap func([]byte)) optFunc {
+	return func(opts *Options) error {
+		opts.ResponseTap = tap
+		return nil
+	}
+}
+
+// WithTimeout bounds each call to the GitHub API to fetch the release
+// metadata. When the timeout expires, the returned error wraps
+// context.DeadlineExceeded.
+func WithTimeout(d time.Duration) optFunc {
+	return func(opts *Options) error {
+		opts.Timeout = d
+		return nil
+	}
+}
+
+// WithDownloadTimeout bounds the time to download each asset, including
+// reading its data. Downloads are not bounded by the metadata timeout as
+// large assets legitimately take longer to transfer. When caching a release,
+// an asset that exceeds the timeout fails without blocking the others.
+func WithDownloadTimeout(d time.Duration) optFunc {
+	return func(opts *Options) error {
+		opts.DownloadTimeout = d
+		return nil
+	}
+}
+
+// WithReleaseID loads the release by its numeric ID. When set, the
+// release ID takes precedence over the tag.
+func WithReleaseID(id int64) optFunc {
+	return func(opts *Options) error {
+		if id < 0 {
+			return fmt.Errorf("invalid release ID %d", id)
+		}
+		opts.ReleaseID = id
+		return nil
+	}
+}
+
+// WithExposeMetadataFile adds a virtual file to the filesystem root that
+// serves the release data as JSON. The file is named ".release.json" by
+// default, use WithMetadataFileName to change it. When exposed, the virtual
+// file shadows any asset with the same name.
+func WithExposeMetadataFile(expose bool) optFunc {
+	return func(opts *Options) error {
+		opts.ExposeMetadataFile = expose
+		return nil
+	}
+}
+
+// WithMetadataFileName sets the name of the virtual release data file.
+func WithMetadataFileName(name string) optFunc {
+	return func(opts *Options) error {
+		if name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("invalid metadata file name %q", name)
+		}
+		opts.MetadataFileName = name
+		return nil
+	}
+}
+
+// WithMemoryCache keeps the data of opened assets in memory, up to maxBytes,
+// to serve repeated reads without fetching them again. When the budget is
+// exceeded, the least recently used assets are evicted. Assets larger than
+// the budget are always streamed from their source. A zero budget disables
+// the memory cache.
+func WithMemoryCache(maxBytes int64) optFunc {
+	return func(opts *Options) error {
+		if maxBytes < 0 {
+			return fmt.Errorf("invalid memory cache size %d", maxBytes)
+		}
+		opts.MemoryCacheSize = maxBytes
+		return nil
+	}
+}
+
+// WithCaseInsensitiveLookup makes Open and Stat match asset names ignoring
+// case when there is no exact match. If more than one asset matches, the
+// lookup fails with ErrAmbiguousName. Lookups are case sensitive by default
+// as required by io/fs.
+func WithCaseInsensitiveLookup(insensitive bool) optFunc {
+	return func(opts *Options) error {
+		opts.CaseInsensitiveLookup = insensitive
+		return nil
+	}
+}
+
+// WithVerifier sets a verifier that checks assets before they are served
+// by Open or written to the cache. The signature of an asset is located by
+// appending the signature suffixes (.sigstore.json, .sigstore and .sig by
+// default) to its name. Assets failing verification return a
+// *VerificationError.
+//
+// Verified data must be read twice, once by the verifier and once by the
+// caller. Assets downloaded from GitHub are spooled to a temporary file
+// while they are verified, use WithMaxDownloadSize to bound its size.
+// Cached files are verified once after they are written.
+func WithVerifier(v AssetVerifier) optFunc {
+	return func(opts *Options) error {
+		opts.Verifier = v
+		return nil
+	}
+}
+
+// WithSignatureSuffixes overrides the suffixes used to locate the
+// signature asset of a file when a verifier is configured.
+func WithSignatureSuffixes(suffixes []string) optFunc {
+	return func(opts *Options) error {
+		opts.SignatureSuffixes = suffixes
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent in the requests to the GitHub
+// API and when downloading assets. GitHub requires all API requests to send
+// a User-Agent; setting a descriptive one identifies your automation and
+// helps GitHub Enterprise admins allowlist it in abuse detection. Defaults
+// to "ghrfs".
+func WithUserAgent(ua string) optFunc {
+	return func(opts *Options) error {
+		opts.UserAgent = ua
+		return nil
+	}
+}
+
+// WithAPIVersion sets the version of the GitHub REST API requested in the
+// X-GitHub-Api-Version header, in the YYYY-MM-DD form used by GitHub.
+// Pinning the version protects ghrfs from changes in the API defaults.
+// GitHub Enterprise Server instances may only support older versions,
+// check the versions listed in the REST API docs for your server release
+// and set one of those. Defaults to 2022-11-28.
+func WithAPIVersion(version string) optFunc {
+	return func(opts *Options) error {
+		if _, err := time.Parse(time.DateOnly, version); err != nil {
+			return fmt.Errorf("invalid API version %q, expected a date like %s", version, defaultAPIVersion)
+		}
+		opts.APIVersion = version
+		return nil
+	}
+}
+
+// WithCacheMetadataName sets the name of the sidecar file where the release
+// data is stored in the cache directory. Use it when a release ships an asset
+// named like the default sidecar (.release-data.json).
+func WithCacheMetadataName(name string) optFunc {
+	return func(opts *Options) error {
+		if name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("invalid cache metadata file name %q", name)
+		}
+		opts.CacheMetadataName = name
+		return nil
+	}
+}
+
+// WithToken sets the token used to authenticate to GitHub. When no token is
+// set, it is read from the GITHUB_TOKEN environment variable, then from
+// GH_TOKEN. The token is only sent to GitHub hosts.
+func WithToken(token string) optFunc {
+	return func(opts *Options) error {
+		opts.Token = token
+		return nil
+	}
+}
+
+// WithAnonymous forces unauthenticated access to GitHub, ignoring the
+// token set with WithToken and any token found in the environment.
+func WithAnonymous(anonymous bool) optFunc {
+	return func(opts *Options) error {
+		opts.Anonymous = anonymous
+		return nil
+	}
+}
+
+// WithMaxDownloadSize limits the number of bytes read from remote assets
+// opened on demand. Reading past the limit returns an error wrapping
+// ErrDownloadTooLarge rather than truncating the data. Assets whose recorded
+// size is within the limit are not checked. Zero disables the limit.
+func WithMaxDownloadSize(n int64) optFunc {
+	return func(opts *Options) error {
+		if n < 0 {
+			return fmt.Errorf("invalid maximum download size %d", n)
+		}
+		opts.MaxDownloadSize = n
+		return nil
+	}
+}
+
+// WithLogger sets the logger used to report what the filesystem is doing:
+// release resolution, cache hits and misses, remote fetches and caching
+// results. Most messages are logged at the debug level. By default, logs
+// are discarded.
+func WithLogger(logger *slog.Logger) optFunc {
+	return func(opts *Options) error {
+		opts.Logger = logger
+		return nil
+	}
+}
+
+// WithResumableCache makes CacheRelease continue downloads interrupted in a
+// previous run instead of starting over. When a cached file is shorter than
+// the asset, the missing data is requested with an HTTP Range request and
+// appended to it. The completed file is checked against the asset size and,
+// if a verifier is configured, verified as a whole.
+func WithResumableCache(resume bool) optFunc {
+	return func(opts *Options) error {
+		opts.ResumableCache = resume
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to call the GitHub API and to
+// download assets. By default, http.DefaultClient is used which honors the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithHTTPClient(client *http.Client) optFunc {
+	return func(opts *Options) error {
+		opts.HTTPClient = client
+		return nil
+	}
+}
+
+// WithProxy routes the API calls and asset downloads through a proxy. The
+// URL scheme can be http, https or socks5. The proxy takes precedence over
+// the proxy environment variables and over the proxy configured in the
+// transport of a client set with WithHTTPClient.
+func WithProxy(proxyURL string) optFunc {
+	return func(opts *Options) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("parsing proxy URL: %w", err)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+		}
+		if u.Host == "" {
+			return fmt.Errorf("proxy URL %q has no host", proxyURL)
+		}
+		opts.Proxy = u
+		return nil
+	}
+}
+
+// WithRootCAs sets the certificate authorities trusted to verify the TLS
+// certificates of the API host and the asset download hosts, replacing the
+// system trust store. Use it with GitHub Enterprise instances whose
+// certificates are issued by an internal CA.
+func WithRootCAs(pool *x509.CertPool) optFunc {
+	return func(opts *Options) error {
+		opts.RootCAs = pool
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the TLS certificates
+// of the API host and the asset download hosts.
+//
+// This is dangerous: anyone able to intercept the connections can read the
+// token and tamper with the release data and assets. Only use it for
+// testing, prefer WithRootCAs to trust an internal CA.
+func WithInsecureSkipVerify(skip bool) optFunc {
+	return func(opts *Options) error {
+		opts.InsecureSkipVerify = skip
+		return nil
+	}
+}
+
+// WithIncludeDrafts allows loading draft releases. GitHub does not serve
+// drafts by tag, so when a tag is not found, the releases list is searched
+// for a draft with the tag. Drafts are only visible to authenticated users
+// with push access to the repository. By default, only published releases
+// are loaded.
+func WithIncludeDrafts(include bool) optFunc {
+	return func(opts *Options) error {
+		opts.IncludeDrafts = include
+		return nil
+	}
+}
+
+// WithPreserveTimestamps sets the modification time of cached files to the
+// time the asset was last updated in GitHub instead of the time it was
+// downloaded. This keeps the cache stable for reproducible builds and tools
+// that compare modification times.
+func WithPreserveTimestamps(preserve bool) optFunc {
+	return func(opts *Options) error {
+		opts.PreserveTimestamps = preserve
+		return nil
+	}
+}
+
+// WithCacheAssets limits CacheRelease to the named assets, the rest are
+// fetched from GitHub when opened. The list takes precedence over the
+// CacheExtensions and CacheMaxSize filters: listed assets are cached even
+// if the filters would skip them. CacheRelease fails if any of the names is
+// not an asset of the release.
+func WithCacheAssets(names ...string) optFunc {
+	return func(opts *Options) error {
+		opts.CacheAssets = names
+		return nil
+	}
+}
+
+// WithSourceArchives lists the source code archives that GitHub generates
+// for the release tag as assets of the filesystem, named
+// <repo>-<tag>.tar.gz and <repo>-<tag>.zip. This is useful for releases
+// that have no uploaded assets. The size of the archives is not known
+// until they are downloaded.
+func WithSourceArchives(include bool) optFunc {
+	return func(opts *Options) error {
+		opts.SourceArchives = include
+		return nil
+	}
+}
+
+// WithNameRewriter sets a function that maps asset names to their path in
+// the filesystem, for example to present assets named bin--linux--tool as
+// bin/linux/tool. Directories are created for the rewritten paths. Only the
+// fs.FS methods (Open, Stat and ReadDir) use the rewritten paths, assets are
+// still downloaded and cached under their GitHub names. Rewritten paths that
+// are not valid fs paths are ignored.
+func WithNameRewriter(rewriter func(string) string) optFunc {
+	return func(opts *Options) error {
+		opts.NameRewriter = rewriter
+		return nil
+	}
+}
+
+// WithDeduplicateNames renames assets with duplicate names instead of
+// failing to load the release. GitHub does not allow duplicate names but
+// some imported releases have them. The second and later assets with the
+// same name get a numeric suffix before the extension, for example
+// tool.tar.gz and tool-2.tar.gz.
+func WithDeduplicateNames(dedupe bool) optFunc {
+	return func(opts *Options) error {
+		opts.DeduplicateNames = dedupe
+		return nil
+	}
+}
+
+// WithReadDirPrefix scopes the filesystem to the assets whose names start
+// with prefix. The prefix is stripped from the names presented by Open,
+// Stat and ReadDir, so an asset named docs-guide.pdf is opened as guide.pdf
+// with the prefix "docs-". Assets without the prefix are hidden entirely
+// from the fs.FS methods but they are still cached and accessible through
+// the rest of the API using their full names. When combined with
+// WithNameRewriter, the rewriter receives the name without the prefix.
+func WithReadDirPrefix(prefix string) optFunc {
+	return func(opts *Options) error {
+		opts.ReadDirPrefix = prefix
+		return nil
+	}
+}
+
+// WithLazyLoad defers loading the release until the filesystem is first
+// accessed, through Open, Stat, ReadDir or any other method that reads the
+// release. New returns without calling the GitHub API and a failed load is
+// retried on the next access. Methods that can't return an error report an
+// empty release when loading fails.
+func WithLazyLoad(lazy bool) optFunc {
+	return func(opts *Options) error {
+		opts.LazyLoad = lazy
+		return nil
+	}
+}
+
+// WithURLRewriter sets a function that rewrites the GitHub download URL of
+// assets before they are fetched. It can be used to redirect downloads to a
+// local server in tests or to a proxy. Mirror URLs are not rewritten.
+func WithURLRewriter(rewriter func(string) string) optFunc {
+	return func(opts *Options) error {
+		opts.URLRewriter = rewriter
+		return nil
+	}
+}
+
+// WithDownloadVia selects how assets are downloaded. DownloadViaBrowser, the
+// default, fetches the browser download URL of the assets. DownloadViaAPI
+// requests the asset data from its API URL, which redirects to the storage
+// host. Use it in networks that block the browser download domain.
+func WithDownloadVia(via string) optFunc {
+	return func(opts *Options) error {
+		switch via {
+		case DownloadViaBrowser, DownloadViaAPI:
+			opts.DownloadVia = via
+			return nil
+		default:
+			return fmt.Errorf("invalid download method %q, must be %q or %q", via, DownloadViaBrowser, DownloadViaAPI)
+		}
+	}
+}
+
+// WithLabelAsName lets Open and Stat resolve assets by their label in
+// addition to their name. Labels are sanitized into valid paths and are not
+// listed by ReadDir. Asset names always take precedence: a label that
+// collides with the path of another asset or directory is ignored, as are
+// labels shared by more than one asset.
+func WithLabelAsName(useLabels bool) optFunc {
+	return func(opts *Options) error {
+		opts.LabelAsName = useLabels
+		return nil
+	}
+}
+
+// WithExpandArchives lists tar archive assets (optionally gzipped) whose
+// contents are expanded into the filesystem. Each archive is presented as
+// a directory named after the asset, so an entry foo/bar in bundle.tar.gz
+// is opened as bundle.tar.gz/foo/bar. Archives are read when first
+// accessed. The archive data remains available through OpenCachedFile and
+// OpenRemoteFile.
+func WithExpandArchives(names []string) optFunc {
+	return func(opts *Options) error {
+		opts.ExpandArchives = names
+		return nil
+	}
+}
+
+// WithIncludePrereleases makes resolving the latest release pick the most
+// recently published release, even if it is a prerelease. The GitHub latest
+// release endpoint, used by default, never returns prereleases. This option
+// has no effect when resolving the latest release by semantic version.
+func WithIncludePrereleases(include bool) optFunc {
+	return func(opts *Options) error {
+		opts.IncludePrereleases = include
+		return nil
+	}
+}
+
+// WithStrictSize controls whether downloads whose Content-Length differs
+// from the asset size recorded in the release are rejected before reading
+// them. It is enabled by default. A mismatched mirror download falls back
+// to GitHub.
+func WithStrictSize(strict bool) optFunc {
+	return func(opts *Options) error {
+		opts.StrictSize = strict
+		return nil
+	}
+}
+
+// WithCacheLayout sets how files are arranged in the cache directory.
+// CacheLayoutFlat, the default, stores the assets and the release data
+// directly in the cache path. CacheLayoutByTag stores them under
+// <org>/<repo>/<tag>, which lets many releases share a cache path.
+func WithCacheLayout(layout string) optFunc {
+	return func(opts *Options) error {
+		switch layout {
+		case CacheLayoutFlat, CacheLayoutByTag:
+			opts.CacheLayout = layout
+			return nil
+		default:
+			return fmt.Errorf("invalid cache layout %q, must be %q or %q", layout, CacheLayoutFlat, CacheLayoutByTag)
+		}
+	}
+}
+
+// WithFallbackToTagArchive serves the source code of the tag when it has no
+// release. The filesystem then has a single asset, the source archive of
+// the tag named <repo>-<tag>.tar.gz, expanded as a directory (see
+// WithExpandArchives).
+func WithFallbackToTagArchive(fallback bool) optFunc {
+	return func(opts *Options) error {
+		opts.FallbackToTagArchive = fallback
+		return nil
+	}
+}
+
+// WithMaxConcurrentOpens limits the number of remote files open at the same
+// time. Opening a remote file when the limit is reached waits until another
+// one is closed, so callers must close the files they open. Downloads made
+// when caching the release also count towards the limit. Zero means there
+// is no limit.
+func WithMaxConcurrentOpens(n int) optFunc {
+	return func(opts *Options) error {
+		if n < 0 {
+			return fmt.Errorf("max concurrent opens cannot be negative")
+		}
+		opts.MaxConcurrentOpens = n
+		return nil
+	}
+}
+
+// WithMaxBytesPerSecond limits the bandwidth used to download assets. The
+// limit is shared by all the downloads of the filesystem, both the files
+// opened remotely and those fetched when caching the release, so parallel
+// downloads split it. Zero means there is no limit.
+func WithMaxBytesPerSecond(n int64) optFunc {
+	return func(opts *Options) error {
+		if n < 0 {
+			return fmt.Errorf("invalid maximum bytes per second %d", n)
+		}
+		opts.MaxBytesPerSecond = n
+		return nil
+	}
+}
+
+// WithRevalidate makes opening a cached asset check with the server that
+// the cached copy is still current. A conditional request is sent with the
+// ETag and Last-Modified values recorded when the asset was cached. If the
+// server reports the asset changed, it is downloaded again and the cached
+// copy replaced. Assets cached without validators are trusted as before.
+func WithRevalidate(revalidate bool) optFunc {
+	return func(opts *Options) error {
+		opts.Revalidate = revalidate
+		return nil
+	}
+}
+
+// WithFailOnMissingCache makes reading an asset that is missing from the
+// cache, or whose cached copy is invalid, fail with ErrCacheMiss instead of
+// downloading it. This guarantees offline builds never reach the network.
+func WithFailOnMissingCache(fail bool) optFunc {
+	return func(opts *Options) error {
+		opts.FailOnMissingCache = fail
+		return nil
+	}
+}
+
+// WithNameSanitizer sets the function used to turn asset names into file
+// names when writing them to the cache or extracting them. It can clean
+// names or return an error to reject them. Names it returns that would
+// resolve outside of the target directory are always rejected. Defaults
+// to SanitizeName.
+func WithNameSanitizer(sanitizer func(string) (string, error)) optFunc {
+	return func(opts *Options) error {
+		opts.NameSanitizer = sanitizer
+		return nil
+	}
+}
+
+// WithRequirePublished makes loading the release fail with
+// ErrUnpublishedRelease when it is a draft or has no publication date, even
+// if WithIncludeDrafts is set. Use it in automation that must only consume
+// finalized releases.
+func WithRequirePublished(require bool) optFunc {
+	return func(opts *Options) error {
+		opts.RequirePublished = require
+		return nil
+	}
+}
+
+// WithCacheBackend sets the store used to cache the release assets, for
+// example an object store shared by several workers. When no backend is
+// set, assets are cached on disk under CachePath. Only the disk cache
+// supports resumable downloads, cache layouts, preserved timestamps and
+// SyncCache.
+func WithCacheBackend(backend Cache) optFunc {
+	return func(opts *Options) error {
+		opts.CacheBackend = backend
+		return nil
+	}
+}
+
+// WithTracerProvider sets the OpenTelemetry provider of the tracer used to
+// record spans for loading the release, downloading assets and caching
+// them. The spans are annotated with the organization, repository, tag,
+// asset names and byte counts. Defaults to a no-op tracer.
+func WithTracerProvider(tp trace.TracerProvider) optFunc {
+	return func(opts *Options) error {
+		opts.TracerProvider = tp
 		return nil
 	}
 }
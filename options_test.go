@@ -40,3 +40,80 @@ func TestFromURL(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheFilterOptions(t *testing.T) {
+	t.Parallel()
+	rfs, err := New(
+		WithOrganization("example"), WithRepository("repo"), WithLazyLoad(true),
+		WithCacheExtensions([]string{".JSON", "txt"}),
+		WithCacheMaxSize(100),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"json", "txt"}, rfs.Options.CacheExtensions)
+	require.Equal(t, int64(100), rfs.Options.CacheMaxSize)
+
+	for name, size := range map[string]int64{"a.json": 10, "b.TXT": 20, "c.txt": 200, "d.bin": 5} {
+		rfs.Release.Assets = append(rfs.Release.Assets, &AssetFile{FileInfo: FileInfo{IName: name, ISize: size}})
+	}
+	rfs.indexAssets()
+	rfs.loaded.Store(true)
+
+	plan, err := rfs.Plan()
+	require.NoError(t, err)
+	for _, item := range plan {
+		require.Equal(t, item.Name == "a.json" || item.Name == "b.TXT", item.Cache, item.Name)
+	}
+
+	require.Error(t, WithCacheExtensions([]string{"json", "."})(&Options{}))
+	require.Error(t, WithCacheMaxSize(-1)(&Options{}))
+}
+
+func TestMemoryCacheOption(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		size    int64
+		mustErr bool
+	}{
+		{"budget", 1024, false},
+		{"disabled", 0, false},
+		{"negative", -1, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			opts := Options{}
+			err := WithMemoryCache(tc.size)(&opts)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.size, opts.MemoryCacheSize)
+		})
+	}
+}
+
+func TestValidateOptions(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		opts   []optFunc
+		expect string
+	}{
+		{"no-options", nil, "organization is required"},
+		{"no-repository", []optFunc{WithOrganization("example")}, "repository is required"},
+		{"no-host", []optFunc{WithHost(""), WithOrganization("example"), WithRepository("repo")}, "host is required"},
+		{"valid", []optFunc{WithOrganization("example"), WithRepository("repo"), WithLazyLoad(true)}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := New(tc.opts...)
+			if tc.expect == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidOptions)
+			require.ErrorContains(t, err, tc.expect)
+		})
+	}
+}
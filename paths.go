@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// assetPath returns the path of an asset in the filesystem if it differs
+// from its name. When a read dir prefix is set, it is stripped from the
+// name and then, if a name rewriter is configured, the name is passed
+// through it. Rewritten paths that are not valid fs paths are ignored.
+func (rfs *ReleaseFileSystem) assetPath(name string) string {
+	p := strings.TrimPrefix(name, rfs.Options.ReadDirPrefix)
+	if rfs.Options.NameRewriter != nil {
+		p = rfs.Options.NameRewriter(p)
+	}
+	if p == name {
+		return ""
+	}
+	if p == "." || !fs.ValidPath(p) {
+		rfs.logger().Warn("ignoring invalid rewritten asset path", "asset", name, "path", p)
+		return ""
+	}
+	return p
+}
+
+// visible returns true if the asset is part of the filesystem. When a read
+// dir prefix is set, assets whose name doesn't start with it are hidden.
+func (rfs *ReleaseFileSystem) visible(name string) bool {
+	if rfs.Options.ReadDirPrefix == "" {
+		return true
+	}
+	return strings.HasPrefix(name, rfs.Options.ReadDirPrefix) && name != rfs.Options.ReadDirPrefix
+}
+
+// isDir returns true if name is a directory created by rewriting the
+// asset names into paths.
+func (rfs *ReleaseFileSystem) isDir(name string) bool {
+	_, ok := rfs.Release.dirIndex[name]
+	return ok
+}
+
+// subdir returns a directory of the filesystem listing entries.
+func (rfs *ReleaseFileSystem) subdir(name string, entries []fs.DirEntry) *ReleaseDir {
+	return &ReleaseDir{
+		Tag:        path.Base(name),
+		Ctime:      rfs.Release.modTime(),
+		Mtime:      rfs.Release.modTime(),
+		AssetFiles: entries,
+	}
+}
+
+// assetEntry returns the asset as listed in the filesystem. Assets with a
+// rewritten path are returned as a copy named after the path base name.
+func (rfs *ReleaseFileSystem) assetEntry(a *AssetFile) *AssetFile {
+	if a.DisplayPath == "" {
+		return a
+	}
+	info := a.FileInfo
+	info.IName = path.Base(a.DisplayPath)
+	return &AssetFile{
+		URL:         a.URL,
+		APIURL:      a.APIURL,
+		ID:          a.ID,
+		NodeID:      a.NodeID,
+		DisplayPath: a.DisplayPath,
+		FileInfo:    info,
+	}
+}
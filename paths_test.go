@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameRewriter(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{
+		"bin--linux--amd64--tool": []byte("linux amd64"),
+		"bin--linux--arm64--tool": []byte("linux arm64"),
+		"bin--darwin--tool":       []byte("darwin"),
+		"README.md":               []byte("readme"),
+		"bad--..--name":           []byte("invalid"),
+	})
+	rfs.Options.NameRewriter = func(name string) string {
+		return strings.ReplaceAll(name, "--", "/")
+	}
+	rfs.indexAssets()
+
+	require.NoError(t, fstest.TestFS(rfs,
+		"bin/linux/amd64/tool", "bin/linux/arm64/tool", "bin/darwin/tool", "README.md", "bad--..--name",
+	))
+
+	data, err := fs.ReadFile(rfs, "bin/linux/arm64/tool")
+	require.NoError(t, err)
+	require.Equal(t, "linux arm64", string(data))
+
+	entries, err := fs.ReadDir(rfs, "bin/linux")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.True(t, entries[0].IsDir())
+	require.Equal(t, "amd64", entries[0].Name())
+
+	info, err := fs.Stat(rfs, "bin/darwin/tool")
+	require.NoError(t, err)
+	require.Equal(t, "tool", info.Name())
+	require.Equal(t, int64(len("darwin")), info.Size())
+
+	// The raw names are not part of the filesystem but still used
+	// for the cache and the rest of the API.
+	_, err = rfs.Open("bin--darwin--tool")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+	require.FileExists(t, filepath.Join(rfs.Options.CachePath, "bin--darwin--tool"))
+	f, err := rfs.OpenCachedFile("bin--darwin--tool")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	_, err = os.Stat(filepath.Join(rfs.Options.CachePath, "bin"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadDirPrefix(t *testing.T) {
+	t.Parallel()
+	rfs := newCachedTestFS(t, map[string][]byte{
+		"docs-guide.pdf":  []byte("guide"),
+		"docs-readme.md":  []byte("readme"),
+		"tool-linux.tgz":  []byte("tool"),
+		"docs-":           []byte("prefix only"),
+		"other-docs-x.md": []byte("other"),
+	})
+	rfs.Options.ReadDirPrefix = "docs-"
+	rfs.indexAssets()
+
+	require.NoError(t, fstest.TestFS(rfs, "guide.pdf", "readme.md"))
+
+	entries, err := fs.ReadDir(rfs, ".")
+	require.NoError(t, err)
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	require.Equal(t, []string{"guide.pdf", "readme.md"}, names)
+
+	data, err := fs.ReadFile(rfs, "guide.pdf")
+	require.NoError(t, err)
+	require.Equal(t, "guide", string(data))
+
+	// Assets without the prefix and full names are hidden
+	for _, name := range []string{"tool-linux.tgz", "docs-guide.pdf", "other-docs-x.md"} {
+		_, err := rfs.Open(name)
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	}
+}
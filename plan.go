@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+// CachePlanItem records whether CacheRelease would cache an asset.
+type CachePlanItem struct {
+	Name   string
+	Size   int64
+	Cache  bool
+	Reason string // Why the asset is skipped, empty if cached
+}
+
+// CachePlan lists the decisions made for each asset in the release.
+type CachePlan []CachePlanItem
+
+// Size returns the total number of bytes that would be downloaded.
+func (cp CachePlan) Size() int64 {
+	var total int64
+	for _, item := range cp {
+		if item.Cache {
+			total += item.Size
+		}
+	}
+	return total
+}
+
+// Plan evaluates the cache filters in the options (CacheAssets, CacheMaxSize
+// and CacheExtensions) against the release assets and reports which ones
+// CacheRelease would download, without downloading anything. Assets
+// dropped by the asset filter are not part of the release and are not
+// listed.
+func (rfs *ReleaseFileSystem) Plan() (CachePlan, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil, err
+	}
+
+	plan := make(CachePlan, 0, len(rfs.Release.Assets))
+	for _, a := range rfs.Release.Assets {
+		ok, reason := rfs.cacheDecision(a)
+		plan = append(plan, CachePlanItem{
+			Name:   a.Name(),
+			Size:   a.Size(),
+			Cache:  ok,
+			Reason: reason,
+		})
+	}
+	return plan, nil
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+	rfs := &ReleaseFileSystem{
+		Options: Options{
+			CacheMaxSize:    100,
+			CacheExtensions: []string{"json", "txt"},
+		},
+	}
+	for name, size := range map[string]int64{"a.json": 10, "b.txt": 20, "c.txt": 200, "d.bin": 5} {
+		rfs.Release.Assets = append(rfs.Release.Assets, &AssetFile{FileInfo: FileInfo{IName: name, ISize: size}})
+	}
+	rfs.indexAssets()
+
+	plan, err := rfs.Plan()
+	require.NoError(t, err)
+	require.Len(t, plan, 4)
+	require.Equal(t, int64(30), plan.Size())
+
+	for _, item := range plan {
+		switch item.Name {
+		case "a.json", "b.txt":
+			require.True(t, item.Cache)
+			require.Empty(t, item.Reason)
+		case "c.txt":
+			require.False(t, item.Cache)
+			require.Contains(t, item.Reason, "exceeds")
+		case "d.bin":
+			require.False(t, item.Cache)
+			require.Contains(t, item.Reason, "extension")
+		}
+	}
+
+	// Plan must not download or cache anything
+	require.False(t, rfs.Options.Cache)
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"fmt"
+	"io/fs"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// platformAliases maps GOOS and GOARCH values to the spellings commonly
+// found in release asset names.
+var platformAliases = map[string][]string{
+	"darwin":  {"darwin", "macos", "osx"},
+	"windows": {"windows", "win"},
+	"amd64":   {"amd64", "x86_64", "x64"},
+	"arm64":   {"arm64", "aarch64"},
+	"386":     {"386", "i386", "i686"},
+	"arm":     {"arm", "armv6", "armv7", "armhf"},
+}
+
+// UnknownPlatform is the key AssetsByPlatform groups the assets whose
+// platform cannot be detected under.
+const UnknownPlatform = "unknown"
+
+// knownOSes and knownArchs are the GOOS and GOARCH values detected by
+// AssetsByPlatform, in the order they are tried.
+var (
+	knownOSes  = []string{"linux", "darwin", "windows", "freebsd", "openbsd", "netbsd", "android"}
+	knownArchs = []string{"amd64", "arm64", "386", "arm", "ppc64le", "s390x", "riscv64"}
+)
+
+// checksumSuffixes are the extensions of checksum files, which share the
+// platform tokens of the artifacts they describe.
+var checksumSuffixes = []string{".sha256", ".sha512", ".sha256sum", ".sha512sum", ".md5"}
+
+// AssetForPlatform returns the asset built for the goos and goarch platform
+// as detected from its name, for example tool_linux_amd64.tar.gz. Common
+// variants such as x86_64 or aarch64 are recognized. Empty values default
+// to the platform of the running program. Signatures and checksum files are
+// never returned. If no asset matches, the error wraps fs.ErrNotExist. If
+// more than one asset matches, the error wraps ErrAmbiguousName.
+func (rfs *ReleaseFileSystem) AssetForPlatform(goos, goarch string) (*AssetFile, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil, err
+	}
+
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+
+	matches := []*AssetFile{}
+	for _, a := range rfs.Release.Assets {
+		name := strings.ToLower(a.Name())
+		if rfs.isSignature(name) || slices.ContainsFunc(checksumSuffixes, func(s string) bool {
+			return strings.HasSuffix(name, s)
+		}) {
+			continue
+		}
+
+		if hasPlatformToken(name, goos) && hasPlatformToken(name, goarch) {
+			matches = append(matches, a)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no asset found for %s/%s: %w", goos, goarch, fs.ErrNotExist)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, 0, len(matches))
+		for _, a := range matches {
+			names = append(names, a.Name())
+		}
+		return nil, fmt.Errorf(
+			"%w: %s/%s matches %s", ErrAmbiguousName, goos, goarch, strings.Join(names, ", "),
+		)
+	}
+}
+
+// hasPlatformToken returns true if the name contains the platform value or
+// one of its aliases delimited by non alphanumeric characters. Delimiting
+// the tokens keeps arm from matching arm64 or win from matching darwin.
+func hasPlatformToken(name, value string) bool {
+	aliases, ok := platformAliases[value]
+	if !ok {
+		aliases = []string{value}
+	}
+
+	for _, alias := range aliases {
+		for i := 0; ; {
+			j := strings.Index(name[i:], alias)
+			if j < 0 {
+				break
+			}
+			start, end := i+j, i+j+len(alias)
+			if (start == 0 || !isAlphanumeric(name[start-1])) &&
+				(end == len(name) || !isAlphanumeric(name[end])) {
+				return true
+			}
+			i = start + 1
+		}
+	}
+	return false
+}
+
+func isAlphanumeric(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('0' <= c && c <= '9')
+}
+
+// AssetsByPlatform groups the release assets by the platform detected from
+// their names. Keys are "goos/goarch" when both are found, for example
+// "linux/amd64", or just the GOOS value when the name has no architecture,
+// as in universal macOS binaries. Assets with no recognizable OS are
+// grouped under UnknownPlatform. Signatures and checksum files are grouped
+// with the artifacts they describe, as they share their platform tokens.
+func (rfs *ReleaseFileSystem) AssetsByPlatform() map[string][]*AssetFile {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil
+	}
+
+	groups := map[string][]*AssetFile{}
+	for _, a := range rfs.Release.Assets {
+		key := detectPlatform(strings.ToLower(a.Name()))
+		groups[key] = append(groups[key], a)
+	}
+	return groups
+}
+
+// detectPlatform returns the platform key of a lowercased asset name.
+func detectPlatform(name string) string {
+	i := slices.IndexFunc(knownOSes, func(goos string) bool { return hasPlatformToken(name, goos) })
+	if i < 0 {
+		return UnknownPlatform
+	}
+	j := slices.IndexFunc(knownArchs, func(goarch string) bool { return hasPlatformToken(name, goarch) })
+	if j < 0 {
+		return knownOSes[i]
+	}
+	return knownOSes[i] + "/" + knownArchs[j]
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io/fs"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetForPlatform(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name      string
+		assets    []string
+		goos      string
+		goarch    string
+		expect    string
+		expectErr error
+	}{
+		{
+			"go-style", []string{"tool_linux_amd64.tar.gz", "tool_linux_arm64.tar.gz", "tool_darwin_arm64.tar.gz"},
+			"linux", "arm64", "tool_linux_arm64.tar.gz", nil,
+		},
+		{
+			"aliases", []string{"tool-x86_64-unknown-linux-gnu.tar.gz", "tool-aarch64-apple-darwin.tar.gz"},
+			"darwin", "arm64", "tool-aarch64-apple-darwin.tar.gz", nil,
+		},
+		{
+			"x86_64", []string{"tool-Linux-x86_64", "tool-Linux-aarch64"},
+			"linux", "amd64", "tool-Linux-x86_64", nil,
+		},
+		{
+			"arm-not-arm64", []string{"tool-linux-arm64", "tool-linux-armv7"},
+			"linux", "arm", "tool-linux-armv7", nil,
+		},
+		{
+			"skips-checksums-and-signatures",
+			[]string{"tool-linux-amd64", "tool-linux-amd64.sha256", "tool-linux-amd64.sig"},
+			"linux", "amd64", "tool-linux-amd64", nil,
+		},
+		{
+			"none", []string{"tool-linux-amd64"}, "windows", "amd64", "", fs.ErrNotExist,
+		},
+		{
+			"ambiguous", []string{"tool-linux-amd64.tar.gz", "tool-linux-amd64.zip"},
+			"linux", "amd64", "", ErrAmbiguousName,
+		},
+		{
+			"defaults", []string{"tool-" + runtime.GOOS + "-" + runtime.GOARCH},
+			"", "", "tool-" + runtime.GOOS + "-" + runtime.GOARCH, nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := &ReleaseFileSystem{Options: defaultOptions}
+			for _, name := range tc.assets {
+				rfs.Release.Assets = append(rfs.Release.Assets, &AssetFile{FileInfo: FileInfo{IName: name}})
+			}
+			rfs.indexAssets()
+
+			a, err := rfs.AssetForPlatform(tc.goos, tc.goarch)
+			if tc.expectErr != nil {
+				require.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, a.Name())
+		})
+	}
+}
+
+func TestAssetsByPlatform(t *testing.T) {
+	t.Parallel()
+	rfs := &ReleaseFileSystem{Options: defaultOptions}
+	for _, name := range []string{
+		"tool_linux_amd64.tar.gz", "tool_linux_amd64.tar.gz.sig", "tool-aarch64-unknown-linux-gnu.tar.gz",
+		"tool-x86_64-apple-darwin.zip", "tool-macos-universal.zip", "tool-win-x64.exe", "checksums.txt", "sbom.spdx.json",
+	} {
+		rfs.Release.Assets = append(rfs.Release.Assets, &AssetFile{FileInfo: FileInfo{IName: name}})
+	}
+	rfs.indexAssets()
+
+	groups := map[string][]string{}
+	for platform, assets := range rfs.AssetsByPlatform() {
+		for _, a := range assets {
+			groups[platform] = append(groups[platform], a.Name())
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"linux/amd64":   {"tool_linux_amd64.tar.gz", "tool_linux_amd64.tar.gz.sig"},
+		"linux/arm64":   {"tool-aarch64-unknown-linux-gnu.tar.gz"},
+		"darwin/amd64":  {"tool-x86_64-apple-darwin.zip"},
+		"darwin":        {"tool-macos-universal.zip"},
+		"windows/amd64": {"tool-win-x64.exe"},
+		UnknownPlatform: {"checksums.txt", "sbom.spdx.json"},
+	}, groups)
+}
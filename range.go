@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+)
+
+// readCloser combines a reader and the closer of its underlying stream.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// byteRange is the part of an asset data requested with a Range header.
+// A length of zero or less reads through the end of the asset.
+type byteRange struct {
+	offset int64
+	length int64
+}
+
+// header returns the headers to request the range. A nil range requests
+// the whole asset so it returns no headers.
+func (r *byteRange) header() http.Header {
+	if r == nil {
+		return nil
+	}
+	h := http.Header{}
+	if r.length > 0 {
+		h.Set("Range", fmt.Sprintf("bytes=%d-%d", r.offset, r.offset+r.length-1))
+	} else {
+		h.Set("Range", fmt.Sprintf("bytes=%d-", r.offset))
+	}
+	return h
+}
+
+// apply returns a stream that reads the range from the body of a response
+// with the status code. If the server ignored the range and returned the
+// whole file, the data up to the offset is discarded.
+func (r *byteRange) apply(stream io.ReadCloser, status int) (io.ReadCloser, error) {
+	if status == http.StatusOK && r.offset > 0 {
+		if _, err := io.CopyN(io.Discard, stream, r.offset); err != nil {
+			stream.Close() //nolint:errcheck,gosec
+			return nil, fmt.Errorf("seeking to range offset: %w", err)
+		}
+	}
+	if r.length > 0 {
+		stream = &readCloser{Reader: io.LimitReader(stream, r.length), Closer: stream}
+	}
+	return stream, nil
+}
+
+// OpenRange returns a file that reads length bytes from the named asset,
+// starting at offset. The data is requested using an HTTP Range request.
+// If the server does not support ranges and returns the whole file, the
+// data up to offset is read and discarded. If length is zero or negative,
+// the file reads through the end of the asset.
+func (rfs *ReleaseFileSystem) OpenRange(name string, offset, length int64) (fs.File, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	i, err := rfs.lookup(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	f, err := rfs.openRange(context.Background(), rfs.Release.Assets[i].Name(), offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// openRange fetches a range of bytes of the asset with the GitHub name
+// name using ctx for the request. The request takes the same path as the
+// downloads of whole assets.
+func (rfs *ReleaseFileSystem) openRange(ctx context.Context, name string, offset, length int64) (*AssetFile, error) {
+	i, ok := rfs.Release.fileIndex[name]
+	if !ok {
+		return nil, fmt.Errorf("opening %q: %w", name, fs.ErrNotExist)
+	}
+	asset := rfs.Release.Assets[i]
+
+	if offset < 0 {
+		return nil, errors.New("range offset cannot be negative")
+	}
+
+	// Clamp the range to the end of the file when we know its size
+	if asset.Size() > 0 && (length <= 0 || offset+length > asset.Size()) {
+		length = asset.Size() - offset
+		if length <= 0 {
+			return nil, fmt.Errorf("range offset %d is past the end of %q", offset, name)
+		}
+	}
+
+	return rfs.openRemote(ctx, name, &byteRange{offset: offset, length: length})
+}
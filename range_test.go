@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenRange(t *testing.T) {
+	t.Parallel()
+	data := []byte("0123456789abcdefghij")
+
+	// ranged serves the data honoring range requests, full ignores them
+	ranged := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "asset.bin", time.Time{}, bytes.NewReader(data))
+	}))
+	t.Cleanup(ranged.Close)
+	full := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data) //nolint:errcheck,gosec
+	}))
+	t.Cleanup(full.Close)
+
+	for _, tc := range []struct {
+		name    string
+		url     string
+		offset  int64
+		length  int64
+		expect  string
+		mustErr bool
+	}{
+		{"ranged", ranged.URL, 5, 4, "5678", false},
+		{"ranged-to-end", ranged.URL, 15, 0, "fghij", false},
+		{"ranged-clamped", ranged.URL, 18, 10, "ij", false},
+		{"fallback", full.URL, 5, 4, "5678", false},
+		{"fallback-to-end", full.URL, 15, 0, "fghij", false},
+		{"past-end", ranged.URL, 20, 2, "", true},
+		{"negative", ranged.URL, -1, 2, "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := &ReleaseFileSystem{}
+			rfs.Release.Assets = []*AssetFile{
+				{URL: tc.url, FileInfo: FileInfo{IName: "asset.bin", ISize: int64(len(data))}},
+			}
+			rfs.indexAssets()
+
+			f, err := rfs.OpenRange("asset.bin", tc.offset, tc.length)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer f.Close() //nolint:errcheck
+
+			got, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, string(got))
+
+			info, err := f.Stat()
+			require.NoError(t, err)
+			require.Equal(t, int64(len(tc.expect)), info.Size())
+		})
+	}
+}
+
+func TestOpenRangeLookup(t *testing.T) {
+	t.Parallel()
+	data := []byte("0123456789")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "asset.bin", time.Time{}, bytes.NewReader(data))
+	}))
+	t.Cleanup(srv.Close)
+
+	rfs := &ReleaseFileSystem{Options: defaultOptions}
+	rfs.Options.NameRewriter = func(s string) string { return strings.ReplaceAll(s, "--", "/") }
+	rfs.Options.CaseInsensitiveLookup = true
+	require.NoError(t, WithMaxConcurrentOpens(1)(&rfs.Options))
+	rfs.Release.Assets = []*AssetFile{
+		{URL: srv.URL, FileInfo: FileInfo{IName: "bin--asset.bin", ISize: int64(len(data))}},
+	}
+	rfs.indexAssets()
+
+	// Ranges are opened with the paths accepted by Open
+	for _, name := range []string{"bin/asset.bin", "BIN/Asset.bin"} {
+		f, err := rfs.OpenRange(name, 2, 3)
+		require.NoError(t, err, name)
+		got, err := io.ReadAll(f)
+		require.NoError(t, err)
+		require.Equal(t, "234", string(got))
+		require.NoError(t, f.Close())
+	}
+	_, err := rfs.OpenRange("bin--asset.bin", 0, 1)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	// Open ranges count towards the limit of remote files open at once
+	f, err := rfs.OpenRange("bin/asset.bin", 0, 1)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	_, err = rfs.OpenRemoteFileContext(ctx, "bin--asset.bin")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NoError(t, f.Close())
+}
+
+func TestRangeDownloadViaAPI(t *testing.T) {
+	t.Parallel()
+	data := []byte("0123456789abcdefghij")
+
+	// The API asset endpoint only serves authenticated requests. Requests
+	// for the whole asset are counted to check downloads are resumed.
+	var full atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") == "" {
+			full.Add(1)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Accept") != "application/octet-stream" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		http.ServeContent(w, r, "asset.bin", time.Time{}, bytes.NewReader(data))
+	}))
+	t.Cleanup(srv.Close)
+
+	newFS := func(t *testing.T) *ReleaseFileSystem {
+		t.Helper()
+		rfs := &ReleaseFileSystem{Options: defaultOptions}
+		require.NoError(t, WithHost("127.0.0.1")(&rfs.Options))
+		require.NoError(t, WithToken("test-token")(&rfs.Options))
+		require.NoError(t, WithDownloadVia(DownloadViaAPI)(&rfs.Options))
+		rfs.Release.Assets = []*AssetFile{{
+			URL:      "https://github.com/example/repo/releases/download/v1.0.0/asset.bin",
+			APIURL:   srv.URL + "/repos/example/repo/releases/assets/1",
+			FileInfo: FileInfo{IName: "asset.bin", ISize: int64(len(data))},
+		}}
+		rfs.indexAssets()
+		return rfs
+	}
+
+	t.Run("range", func(t *testing.T) {
+		t.Parallel()
+		f, err := newFS(t).OpenRange("asset.bin", 5, 4)
+		require.NoError(t, err)
+		defer f.Close() //nolint:errcheck
+		got, err := io.ReadAll(f)
+		require.NoError(t, err)
+		require.Equal(t, "5678", string(got))
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		t.Parallel()
+		rfs := newFS(t)
+		rfs.Options.CachePath = t.TempDir()
+		rfs.Options.ResumableCache = true
+		path := filepath.Join(rfs.Options.CachePath, "asset.bin")
+		require.NoError(t, os.WriteFile(path, data[:10], 0o600))
+
+		require.NoError(t, rfs.CacheRelease())
+		cached, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, data, cached)
+		require.Zero(t, full.Load())
+	})
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/time/rate"
+)
+
+// maxRateBurst is the largest number of bytes read at once from a rate
+// limited download.
+const maxRateBurst = 32 * 1024
+
+// rateLimiter returns the limiter shared by all downloads or nil if the
+// download bandwidth is not limited.
+func (rfs *ReleaseFileSystem) rateLimiter() *rate.Limiter {
+	if rfs.Options.MaxBytesPerSecond <= 0 {
+		return nil
+	}
+	rfs.limiterOnce.Do(func() {
+		burst := min(rfs.Options.MaxBytesPerSecond, maxRateBurst)
+		rfs.limiter = rate.NewLimiter(rate.Limit(rfs.Options.MaxBytesPerSecond), int(burst))
+	})
+	return rfs.limiter
+}
+
+// throttle wraps a download stream to read it no faster than the
+// bandwidth set with WithMaxBytesPerSecond.
+func (rfs *ReleaseFileSystem) throttle(ctx context.Context, rc io.ReadCloser) io.ReadCloser {
+	limiter := rfs.rateLimiter()
+	if limiter == nil {
+		return rc
+	}
+	return &rateLimitedReader{ReadCloser: rc, ctx: ctx, limiter: limiter}
+}
+
+// rateLimitedReader waits for the limiter to allow each chunk of data
+// before returning it.
+type rateLimitedReader struct {
+	io.ReadCloser
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+func (rr *rateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) > rr.limiter.Burst() {
+		p = p[:rr.limiter.Burst()]
+	}
+	n, err := rr.ReadCloser.Read(p)
+	if n > 0 {
+		if werr := rr.limiter.WaitN(rr.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
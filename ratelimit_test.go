@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxBytesPerSecond(t *testing.T) {
+	t.Parallel()
+	a := bytes.Repeat([]byte("a"), 1000)
+	b := bytes.Repeat([]byte("b"), 500)
+	rfs, _ := newRemoteTestFS(t, map[string][]byte{"a.txt": a, "b.txt": b})
+	require.NoError(t, WithMaxBytesPerSecond(1000)(&rfs.Options))
+
+	// The limiter starts with a full burst of 1000 bytes, the remaining 500
+	// bytes of both downloads take at least half a second.
+	start := time.Now()
+	for name, expected := range map[string][]byte{"a.txt": a, "b.txt": b} {
+		f, err := rfs.OpenRemoteFile(name)
+		require.NoError(t, err)
+		data, err := io.ReadAll(f)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.Equal(t, expected, data)
+	}
+	require.GreaterOrEqual(t, time.Since(start), 450*time.Millisecond)
+
+	require.Error(t, WithMaxBytesPerSecond(-1)(&Options{}))
+}
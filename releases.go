@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	releasesListMask = `repos/%s/%s/releases?per_page=%d&page=%d`
+	releasesPageSize = 100
+)
+
+// releaseSummary captures the release fields needed to choose a
+// release from the list returned by the API.
+type releaseSummary struct {
+	ID          int64     `json:"id"`
+	Tag         string    `json:"tag_name"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// listReleases pages through the repository releases list and returns
+// all the releases returned by the API.
+func (rfs *ReleaseFileSystem) listReleases(ctx context.Context) ([]releaseSummary, error) {
+	ret := []releaseSummary{}
+	for page := 1; ; page++ {
+		releases, err := rfs.listReleasesPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, releases...)
+		if len(releases) < releasesPageSize {
+			return ret, nil
+		}
+	}
+}
+
+// listReleasesPage fetches a single page of the releases list.
+func (rfs *ReleaseFileSystem) listReleasesPage(ctx context.Context, page int) ([]releaseSummary, error) {
+	ctx, cancel := withTimeout(ctx, rfs.Options.Timeout)
+	defer cancel()
+
+	resp, err := rfs.client.Call(ctx, "GET", fmt.Sprintf(
+		releasesListMask, rfs.Options.Organization, rfs.Options.Repository, releasesPageSize, page,
+	), nil)
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close() //nolint:errcheck,gosec
+		}
+		return nil, fmt.Errorf("listing releases: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode > 399 || resp.StatusCode < 200 {
+		return nil, fmt.Errorf("HTTP error %d when listing releases", resp.StatusCode)
+	}
+
+	releases := []releaseSummary{}
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("decoding releases list: %w", err)
+	}
+	return releases, nil
+}
+
+// resolveLatestSemver lists the repository releases and returns the
+// tag of the release with the highest semantic version.
+func (rfs *ReleaseFileSystem) resolveLatestSemver(ctx context.Context) (string, error) {
+	releases, err := rfs.listReleases(ctx)
+	if err != nil {
+		return "", err
+	}
+	return highestSemverTag(releases, rfs.Options.LatestSemverPrerelease)
+}
+
+// highestSemverTag returns the tag of the highest semver release in the
+// list. Drafts and tags that don't parse as semantic versions are ignored.
+// Prereleases are only considered when includePrerelease is true.
+func highestSemverTag(releases []releaseSummary, includePrerelease bool) (string, error) {
+	var tag, version string
+	for _, r := range releases {
+		if r.Draft {
+			continue
+		}
+
+		// x/mod/semver requires the "v" prefix
+		v := r.Tag
+		if !strings.HasPrefix(v, "v") {
+			v = "v" + v
+		}
+		if !semver.IsValid(v) {
+			continue
+		}
+
+		if semver.Prerelease(v) != "" && !includePrerelease {
+			continue
+		}
+
+		if version == "" || semver.Compare(v, version) > 0 {
+			tag = r.Tag
+			version = v
+		}
+	}
+
+	if tag == "" {
+		return "", errors.New("no releases tagged with a semantic version found")
+	}
+	return tag, nil
+}
+
+// resolveNewest lists the repository releases and returns the ID of the
+// most recently published one, prereleases included.
+func (rfs *ReleaseFileSystem) resolveNewest(ctx context.Context) (int64, error) {
+	releases, err := rfs.listReleases(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return newestReleaseID(releases)
+}
+
+// newestReleaseID returns the ID of the release with the latest publication
+// date in the list. Drafts are ignored as they are not published.
+func newestReleaseID(releases []releaseSummary) (int64, error) {
+	var newest *releaseSummary
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft {
+			continue
+		}
+		if newest == nil || r.PublishedAt.After(newest.PublishedAt) {
+			newest = r
+		}
+	}
+
+	if newest == nil {
+		return 0, errors.New("no published releases found")
+	}
+	return newest.ID, nil
+}
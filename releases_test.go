@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighestSemverTag(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name       string
+		releases   []releaseSummary
+		prerelease bool
+		expect     string
+		mustErr    bool
+	}{
+		{
+			"normal",
+			[]releaseSummary{{Tag: "v1.0.0"}, {Tag: "v1.10.0"}, {Tag: "v1.9.3"}},
+			false, "v1.10.0", false,
+		},
+		{
+			"no-v-prefix",
+			[]releaseSummary{{Tag: "1.0.0"}, {Tag: "2.1.0"}},
+			false, "2.1.0", false,
+		},
+		{
+			"invalid-ignored",
+			[]releaseSummary{{Tag: "nightly"}, {Tag: "v0.3.0"}, {Tag: "release-20"}},
+			false, "v0.3.0", false,
+		},
+		{
+			"prerelease-excluded",
+			[]releaseSummary{{Tag: "v1.0.0"}, {Tag: "v2.0.0-rc.1"}},
+			false, "v1.0.0", false,
+		},
+		{
+			"prerelease-included",
+			[]releaseSummary{{Tag: "v1.0.0"}, {Tag: "v2.0.0-rc.1"}},
+			true, "v2.0.0-rc.1", false,
+		},
+		{
+			"drafts-ignored",
+			[]releaseSummary{{Tag: "v1.0.0"}, {Tag: "v3.0.0", Draft: true}},
+			false, "v1.0.0", false,
+		},
+		{
+			"none-valid",
+			[]releaseSummary{{Tag: "nightly"}, {Tag: "latest"}},
+			false, "", true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tag, err := highestSemverTag(tc.releases, tc.prerelease)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, tag)
+		})
+	}
+}
+
+func TestIncludePrereleases(t *testing.T) {
+	t.Parallel()
+	const releases = `[
+		{"id": 1, "tag_name": "v1.0.0", "published_at": "2025-01-10T00:00:00Z"},
+		{"id": 2, "tag_name": "v1.1.0-rc.1", "prerelease": true, "published_at": "2025-02-10T00:00:00Z"},
+		{"id": 3, "tag_name": "v2.0.0", "draft": true}
+	]`
+	responses := map[string]fakeResponse{
+		"repos/example/repo/releases?per_page=100&page=1": {http.StatusOK, releases},
+		"repos/example/repo/releases/latest":              {http.StatusOK, `{"id": 1, "tag_name": "v1.0.0"}`},
+		"repos/example/repo/releases/2":                   {http.StatusOK, `{"id": 2, "tag_name": "v1.1.0-rc.1", "prerelease": true}`},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		include bool
+		expect  string
+	}{
+		{"default", false, "v1.0.0"},
+		{"include", true, "v1.1.0-rc.1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := &ReleaseFileSystem{Options: defaultOptions, client: &fakeCaller{responses: responses}}
+			rfs.Options.Organization = "example"
+			rfs.Options.Repository = "repo"
+			rfs.Options.IncludePrereleases = tc.include
+
+			require.NoError(t, rfs.LoadRelease())
+			require.Equal(t, tc.expect, rfs.ResolvedTag())
+		})
+	}
+
+	_, err := newestReleaseID([]releaseSummary{{ID: 3, Draft: true}})
+	require.Error(t, err)
+}
+
+func TestLatestRelease(t *testing.T) {
+	t.Parallel()
+	const latestEndpoint = "repos/example/repo/releases/latest"
+	for _, tc := range []struct {
+		name string
+		opts []optFunc
+	}{
+		{"unset-tag", nil},
+		{"empty-tag", []optFunc{WithTag("")}},
+		{"latest-tag", []optFunc{WithTag("latest")}},
+		{"with-latest", []optFunc{WithTag("v0.1.0"), WithLatest()}},
+		{"overrides-id", []optFunc{WithReleaseID(7), WithLatest()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			caller := &fakeCaller{responses: map[string]fakeResponse{
+				latestEndpoint: {http.StatusOK, `{"id": 1, "tag_name": "v1.0.0"}`},
+			}}
+			rfs := &ReleaseFileSystem{Options: defaultOptions, client: caller}
+			rfs.Options.Organization = "example"
+			rfs.Options.Repository = "repo"
+			for _, fn := range tc.opts {
+				require.NoError(t, fn(&rfs.Options))
+			}
+
+			require.True(t, isLatestTag(rfs.Options.Tag))
+			require.NoError(t, rfs.LoadRelease())
+			require.Equal(t, []string{latestEndpoint}, caller.requests)
+			require.Equal(t, "v1.0.0", rfs.ResolvedTag())
+		})
+	}
+}
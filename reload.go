@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Reload queries the GitHub API again and refreshes the release data. This
+// picks up tags that were moved and assets that were uploaded or removed
+// after the filesystem was created.
+//
+// If the release is cached, the cache directory is reconciled: assets that
+// changed are downloaded again and those no longer in the release are
+// removed. Files that are already open remain valid.
+//
+// Reload must not be called concurrently with other filesystem operations.
+func (rfs *ReleaseFileSystem) Reload(ctx context.Context) error {
+	data, err := rfs.fetchRelease(ctx)
+	if err != nil {
+		return fmt.Errorf("reloading release: %w", err)
+	}
+
+	previous := map[string]*AssetFile{}
+	for _, a := range rfs.Release.Assets {
+		previous[a.Name()] = a
+	}
+
+	// With the by-tag layout, the cache directory moves with the tag
+	previousDir := rfs.cacheDir()
+	rfs.indexRelease(data)
+	rfs.loaded.Store(true)
+	moved := rfs.cacheDir() != previousDir
+
+	// Find the assets that are new or changed since the last load. When
+	// the cache directory moved, none of the assets are cached yet.
+	update := []*AssetFile{}
+	for _, a := range rfs.Release.Assets {
+		old, ok := previous[a.Name()]
+		delete(previous, a.Name())
+		if ok && !moved && !assetChanged(old, a) {
+			a.CacheDigest = old.CacheDigest
+			a.ETag, a.LastModified = old.ETag, old.LastModified
+			continue
+		}
+		update = append(update, a)
+	}
+
+	// Whatever is left in the previous map was removed from the release
+	stale := make([]string, 0, len(previous)+len(update))
+	for name := range previous {
+		stale = append(stale, name)
+	}
+	for _, a := range update {
+		stale = append(stale, a.Name())
+	}
+
+	if mc := rfs.memoryCache(); mc != nil {
+		for _, name := range stale {
+			mc.delete(name)
+		}
+	}
+
+	if !rfs.Options.Cache || (rfs.Options.CacheBackend == nil && rfs.Options.CachePath == "") {
+		return nil
+	}
+
+	// Remove the stale files from the previous cache directory. Cache
+	// backends can't delete files, changed assets are overwritten when
+	// stored again.
+	errs := []error{}
+	if rfs.Options.CacheBackend == nil {
+		errs = rfs.removeCached(previousDir, stale)
+		if err := rfs.prepareCacheDir(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+	}
+
+	if err := rfs.writeReleaseData(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := rfs.cacheAssets(ctx, update); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// removeCached deletes the named assets from the cache directory dir.
+func (rfs *ReleaseFileSystem) removeCached(dir string, names []string) []error {
+	errs := []error{}
+	for _, name := range names {
+		path, err := rfs.localPath(dir, name)
+		if err != nil {
+			continue // Unsafe names are never written to the cache
+		}
+		rfs.verifiedFiles.Delete(path)
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("removing cached %q: %w", name, err))
+		}
+	}
+	return errs
+}
+
+// assetChanged returns true if the asset data was changed between two
+// loads of the release.
+func assetChanged(previous, current *AssetFile) bool {
+	return previous.ID != current.ID ||
+		previous.Size() != current.Size() ||
+		!previous.ModTime().Equal(current.ModTime())
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/carabiner-dev/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload(t *testing.T) {
+	t.Parallel()
+	const (
+		releasePath = "repos/example/repo/releases/tags/v1.0.0"
+		downloadURL = "https://github.com/example/repo/releases/download/v1.0.0/"
+	)
+	caller := &fakeCaller{responses: map[string]fakeResponse{
+		releasePath: {http.StatusOK, `{"tag_name":"v1.0.0","assets":[` +
+			`{"id":1,"name":"a.txt","size":3,"browser_download_url":"` + downloadURL + `a.txt"},` +
+			`{"id":2,"name":"b.txt","size":3,"browser_download_url":"` + downloadURL + `b.txt"},` +
+			`{"id":3,"name":"c.txt","size":3,"browser_download_url":"` + downloadURL + `c.txt"}]}`},
+		downloadURL + "a.txt": {http.StatusOK, "aaa"},
+		downloadURL + "b.txt": {http.StatusOK, "bbb"},
+		downloadURL + "c.txt": {http.StatusOK, "ccc"},
+	}}
+	client, err := github.NewClient(github.WithCaller(caller))
+	require.NoError(t, err)
+
+	cache := t.TempDir()
+	rfs := &ReleaseFileSystem{
+		Options: Options{
+			Organization: "example", Repository: "repo", Tag: "v1.0.0",
+			Cache: true, CachePath: cache, ParallelDownloads: 2,
+		},
+		client:      client,
+		assetCaller: caller,
+	}
+	require.NoError(t, rfs.LoadRelease())
+
+	// Keep a handle open across the reload
+	open, err := rfs.Open("a.txt")
+	require.NoError(t, err)
+
+	// Asset a.txt is re-uploaded, b.txt is deleted and d.txt is added
+	caller.responses[releasePath] = fakeResponse{http.StatusOK, `{"tag_name":"v1.0.0","assets":[` +
+		`{"id":10,"name":"a.txt","size":5,"browser_download_url":"` + downloadURL + `a.txt"},` +
+		`{"id":3,"name":"c.txt","size":3,"browser_download_url":"` + downloadURL + `c.txt"},` +
+		`{"id":4,"name":"d.txt","size":3,"browser_download_url":"` + downloadURL + `d.txt"}]}`}
+	caller.responses[downloadURL+"a.txt"] = fakeResponse{http.StatusOK, "aaaaa"}
+	caller.responses[downloadURL+"d.txt"] = fakeResponse{http.StatusOK, "ddd"}
+	caller.requests = nil
+
+	require.NoError(t, rfs.Reload(t.Context()))
+
+	// Only the changed and new assets are downloaded
+	require.ElementsMatch(t, []string{releasePath, downloadURL + "a.txt", downloadURL + "d.txt"}, caller.requests)
+
+	for name, expect := range map[string]string{"a.txt": "aaaaa", "c.txt": "ccc", "d.txt": "ddd"} {
+		data, err := os.ReadFile(filepath.Join(cache, name))
+		require.NoError(t, err)
+		require.Equal(t, expect, string(data))
+	}
+	require.NoFileExists(t, filepath.Join(cache, "b.txt"))
+
+	_, err = rfs.Stat("b.txt")
+	require.Error(t, err)
+	_, err = rfs.Stat("d.txt")
+	require.NoError(t, err)
+
+	// The handle opened before reloading still reads the old data
+	data, err := io.ReadAll(open)
+	require.NoError(t, err)
+	require.Equal(t, "aaa", string(data))
+	require.NoError(t, open.Close())
+}
+
+func TestReloadLazy(t *testing.T) {
+	t.Parallel()
+	const releasePath = "repos/example/repo/releases/tags/v1.0.0"
+	var buf bytes.Buffer
+	rfs, err := New(
+		WithOrganization("example"), WithRepository("repo"), WithTag("v1.0.0"), WithLazyLoad(true),
+		WithLogger(slog.New(slog.NewTextHandler(&buf, nil))),
+	)
+	require.NoError(t, err)
+	caller := &fakeCaller{responses: map[string]fakeResponse{
+		releasePath: {http.StatusOK, `{"tag_name":"v1.0.0","assets":[{"name":"a.txt","size":"big"},{"name":"b.txt","size":3}]}`},
+	}}
+	rfs.client = caller
+
+	// Reloading before the first access loads the release
+	require.NoError(t, rfs.Reload(t.Context()))
+	require.Contains(t, buf.String(), "skipped malformed asset")
+	require.Len(t, rfs.LoadWarnings(), 1)
+
+	_, err = rfs.Stat("b.txt")
+	require.NoError(t, err)
+	require.Equal(t, []string{releasePath}, caller.requests)
+
+	// Releases emptied by a reload are reported
+	caller.responses[releasePath] = fakeResponse{http.StatusOK, `{"tag_name":"v1.0.0","assets":[]}`}
+	require.NoError(t, rfs.Reload(t.Context()))
+	require.Contains(t, buf.String(), "release has no assets")
+}
+
+func TestReloadMovedTag(t *testing.T) {
+	t.Parallel()
+	const (
+		latestPath  = "repos/example/repo/releases/latest"
+		downloadURL = "https://github.com/example/repo/releases/download/"
+	)
+	caller := &fakeCaller{responses: map[string]fakeResponse{
+		latestPath: {http.StatusOK, `{"tag_name":"v1.0.0","assets":[` +
+			`{"id":1,"name":"a.txt","size":3,"browser_download_url":"` + downloadURL + `v1.0.0/a.txt"}]}`},
+		downloadURL + "v1.0.0/a.txt": {http.StatusOK, "aaa"},
+		downloadURL + "v2.0.0/a.txt": {http.StatusOK, "AAAA"},
+	}}
+	client, err := github.NewClient(github.WithCaller(caller))
+	require.NoError(t, err)
+
+	cache := t.TempDir()
+	rfs := &ReleaseFileSystem{
+		Options: Options{
+			Organization: "example", Repository: "repo",
+			Cache: true, CachePath: cache, CacheLayout: CacheLayoutByTag, ParallelDownloads: 2,
+		},
+		client:      client,
+		assetCaller: caller,
+	}
+	require.NoError(t, rfs.LoadRelease())
+	v1 := filepath.Join(cache, "example", "repo", "v1.0.0")
+	require.FileExists(t, filepath.Join(v1, "a.txt"))
+
+	// Latest moves to a new release with the same asset names
+	caller.responses[latestPath] = fakeResponse{http.StatusOK, `{"tag_name":"v2.0.0","assets":[` +
+		`{"id":2,"name":"a.txt","size":4,"browser_download_url":"` + downloadURL + `v2.0.0/a.txt"}]}`}
+	require.NoError(t, rfs.Reload(t.Context()))
+
+	v2 := filepath.Join(cache, "example", "repo", "v2.0.0")
+	require.FileExists(t, filepath.Join(v2, releaseDataFile))
+	data, err := os.ReadFile(filepath.Join(v2, "a.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "AAAA", string(data))
+	require.NoFileExists(t, filepath.Join(v1, "a.txt"))
+
+	data, err = fs.ReadFile(rfs, "a.txt")
+	require.NoError(t, err)
+	require.Equal(t, "AAAA", string(data))
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// resumeCachedFile completes a partially downloaded cache file. The data
+// from offset on is fetched with a range request and appended to the file.
+func (rfs *ReleaseFileSystem) resumeCachedFile(ctx context.Context, a *AssetFile, path string, offset int64) error {
+	// Only part of the data is downloaded, so its digest and validators
+	// are not known
+	a.CacheDigest, a.ETag, a.LastModified = "", "", ""
+	if offset < a.Size() {
+		rfs.logger().DebugContext(ctx, "resuming asset download", "asset", a.Name(), "offset", offset, "size", a.Size())
+		src, err := rfs.openRange(ctx, a.Name(), offset, 0)
+		if err != nil {
+			return fmt.Errorf("resuming %q: %w", a.Name(), err)
+		}
+		defer src.Close() //nolint:errcheck
+
+		dst, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			return fmt.Errorf("resuming %q: %w", a.Name(), err)
+		}
+
+		if _, err := io.Copy(dst, src); err != nil {
+			dst.Close() //nolint:errcheck,gosec
+			return fmt.Errorf("resuming %q: %w", a.Name(), err)
+		}
+
+		if err := dst.Close(); err != nil {
+			return fmt.Errorf("resuming %q: %w", a.Name(), err)
+		}
+	}
+
+	// Check the completed file matches the recorded asset size
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("checking resumed file %q: %w", a.Name(), err)
+	}
+	if info.Size() != a.Size() {
+		os.Remove(path) //nolint:errcheck,gosec
+		return fmt.Errorf("resumed file %q is %d bytes, expected %d", a.Name(), info.Size(), a.Size())
+	}
+
+	if err := rfs.setCachedTimes(a, path); err != nil {
+		return err
+	}
+
+	return rfs.verifyCachedFile(ctx, a)
+}
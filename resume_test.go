@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumableCache(t *testing.T) {
+	t.Parallel()
+	data := []byte("0123456789abcdefghij")
+
+	for _, tc := range []struct {
+		name    string
+		resume  bool
+		partial string
+		ranges  bool
+		// failRanges makes the server reject range requests
+		failRanges bool
+		requests   []string
+	}{
+		{"resume", true, "0123456789", true, false, []string{"bytes=10-19"}},
+		{"resume-no-range-support", true, "0123456789", false, false, []string{"bytes=10-19"}},
+		{"resume-failed", true, "0123456789", true, true, []string{"bytes=10-19", ""}},
+		{"complete", true, string(data), true, false, []string{}},
+		{"disabled", false, "0123456789", true, false, []string{""}},
+		{"nothing-to-resume", true, "", true, false, []string{""}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var mtx sync.Mutex
+			requests := []string{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mtx.Lock()
+				requests = append(requests, r.Header.Get("Range"))
+				mtx.Unlock()
+				if tc.failRanges && r.Header.Get("Range") != "" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				if tc.ranges {
+					http.ServeContent(w, r, "asset.bin", time.Time{}, bytes.NewReader(data))
+					return
+				}
+				w.Write(data) //nolint:errcheck,gosec
+			}))
+			t.Cleanup(srv.Close)
+
+			cache := t.TempDir()
+			if tc.partial != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(cache, "asset.bin"), []byte(tc.partial), 0o600))
+			}
+
+			opts := defaultOptions
+			opts.CachePath = cache
+			opts.ResumableCache = tc.resume
+			rfs := &ReleaseFileSystem{Options: opts}
+			rfs.Release.Assets = []*AssetFile{
+				{URL: srv.URL + "/asset.bin", FileInfo: FileInfo{IName: "asset.bin", ISize: int64(len(data))}},
+			}
+			rfs.indexAssets()
+
+			require.NoError(t, rfs.CacheRelease())
+			cached, err := os.ReadFile(filepath.Join(cache, "asset.bin"))
+			require.NoError(t, err)
+			require.Equal(t, data, cached)
+			require.Equal(t, tc.requests, requests)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RetryError is returned when an operation retried with WithRetry fails
+// after more than one attempt. It wraps the error of the last attempt.
+type RetryError struct {
+	errs []error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("failed after %d attempts: %v", len(e.errs), e.errs[len(e.errs)-1])
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryError) Unwrap() error {
+	return e.errs[len(e.errs)-1]
+}
+
+// Attempts returns the number of attempts made.
+func (e *RetryError) Attempts() int {
+	return len(e.errs)
+}
+
+// Errors returns the error of each attempt, in order.
+func (e *RetryError) Errors() []error {
+	return append([]error(nil), e.errs...)
+}
+
+// transientError marks an error as worth retrying, such as network failures
+// and server errors. It does not change the error message.
+type transientError struct {
+	error
+}
+
+func (e transientError) Unwrap() error {
+	return e.error
+}
+
+// isTransient returns true if err is marked as worth retrying.
+func isTransient(err error) bool {
+	var te transientError
+	return errors.As(err, &te)
+}
+
+// transientStatus returns true if the HTTP status code signals a failure
+// that may go away if the request is sent again.
+func transientStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// retry calls fn until it succeeds, fails with an error that is not
+// transient or the attempts set with WithRetry run out. The wait between
+// attempts doubles each time. When more than one attempt was made, the
+// error returned is a *RetryError, otherwise the error from fn is returned
+// as is.
+func (rfs *ReleaseFileSystem) retry(ctx context.Context, op string, fn func() error) error {
+	attempts := max(rfs.Options.RetryAttempts, 1)
+	errs := []error{}
+loop:
+	for i := range attempts {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+		if !isTransient(err) || i == attempts-1 {
+			break
+		}
+
+		delay := rfs.Options.RetryBackoff << i
+		rfs.logger().InfoContext(ctx, "retrying after failure", "operation", op, "attempt", i+1, "delay", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			break loop
+		}
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return &RetryError{errs: errs}
+}
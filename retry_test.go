@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		failures int32
+		status   int
+		attempts int
+		// expectAttempts is zero when the download succeeds
+		expectAttempts int
+		retryError     bool
+	}{
+		{"first-try", 0, http.StatusServiceUnavailable, 3, 0, false},
+		{"after-retries", 2, http.StatusServiceUnavailable, 3, 0, false},
+		{"exhausted", 5, http.StatusBadGateway, 3, 3, true},
+		{"rate-limited", 5, http.StatusTooManyRequests, 2, 2, true},
+		{"not-retried", 5, http.StatusNotFound, 3, 1, false},
+		{"disabled", 5, http.StatusServiceUnavailable, 1, 1, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tc.failures {
+					w.WriteHeader(tc.status)
+					return
+				}
+				io.WriteString(w, "data") //nolint:errcheck,gosec
+			}))
+			t.Cleanup(srv.Close)
+
+			rfs := &ReleaseFileSystem{Options: defaultOptions}
+			require.NoError(t, WithRetry(tc.attempts, time.Millisecond)(&rfs.Options))
+			rfs.Release.Assets = []*AssetFile{
+				{URL: srv.URL + "/asset.txt", FileInfo: FileInfo{IName: "asset.txt", ISize: 4}},
+			}
+			rfs.indexAssets()
+
+			f, err := rfs.OpenRemoteFile("asset.txt")
+			if tc.expectAttempts == 0 {
+				require.NoError(t, err)
+				require.NoError(t, f.Close())
+				require.Equal(t, tc.failures+1, requests.Load())
+				return
+			}
+			require.Error(t, err)
+			require.Equal(t, int32(tc.expectAttempts), requests.Load())
+
+			var rerr *RetryError
+			require.Equal(t, tc.retryError, errors.As(err, &rerr))
+			if tc.retryError {
+				require.Equal(t, tc.expectAttempts, rerr.Attempts())
+				require.Len(t, rerr.Errors(), tc.expectAttempts)
+			}
+		})
+	}
+
+	t.Run("release", func(t *testing.T) {
+		t.Parallel()
+		const endpoint = "repos/example/repo/releases/tags/v1.0.0"
+		caller := &fakeCaller{responses: map[string]fakeResponse{
+			endpoint: {http.StatusInternalServerError, `{"message":"Server Error"}`},
+		}}
+		rfs := &ReleaseFileSystem{Options: defaultOptions, client: caller}
+		rfs.Options.Organization = "example"
+		rfs.Options.Repository = "repo"
+		rfs.Options.Tag = "v1.0.0"
+		require.NoError(t, WithRetry(2, time.Millisecond)(&rfs.Options))
+
+		err := rfs.LoadRelease()
+		var rerr *RetryError
+		require.ErrorAs(t, err, &rerr)
+		require.Equal(t, 2, rerr.Attempts())
+		require.Len(t, caller.requests, 2)
+	})
+
+	require.Error(t, WithRetry(0, time.Second)(&Options{}))
+	require.Error(t, WithRetry(3, -time.Second)(&Options{}))
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// recordValidators copies the validators of a downloaded file to the asset
+// being cached from it.
+func recordValidators(a *AssetFile, src fs.File) {
+	if f, ok := src.(*AssetFile); ok && f != a {
+		a.ETag, a.LastModified = f.ETag, f.LastModified
+	}
+}
+
+// revalidateCached sends a conditional request to check if the cached copy
+// of an asset is current. If the server returns new data, it replaces the
+// cached copy. Files not cached or cached without validators are not
+// checked.
+func (rfs *ReleaseFileSystem) revalidateCached(ctx context.Context, a *AssetFile) error {
+	if a.ETag == "" && a.LastModified == "" {
+		return nil
+	}
+	// Missing files are handled as cache misses when opened
+	if !rfs.isCached(a) {
+		return nil
+	}
+
+	downloadURL, accept := rfs.downloadURL(a)
+	u, err := url.Parse(downloadURL)
+	if err != nil {
+		return fmt.Errorf("parsing asset URL: %w", err)
+	}
+	hc := rfs.newCaller(u.Hostname())
+	hc.accept = accept
+	hc.header = http.Header{}
+	if a.ETag != "" {
+		hc.header.Set("If-None-Match", a.ETag)
+	}
+	if a.LastModified != "" {
+		hc.header.Set("If-Modified-Since", a.LastModified)
+	}
+
+	ctx, cancel := withTimeout(ctx, rfs.Options.DownloadTimeout)
+	defer cancel()
+	resp, err := hc.RequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if resp != nil {
+		defer resp.Body.Close() //nolint:errcheck
+	}
+	if err != nil {
+		return fmt.Errorf("revalidating %q: %w", a.Name(), err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		rfs.logger().DebugContext(ctx, "cached asset is current", "asset", a.Name())
+		return nil
+	case http.StatusOK:
+	default:
+		return fmt.Errorf("HTTP error %d revalidating %q", resp.StatusCode, a.Name())
+	}
+
+	// The asset changed, cache the data we just got
+	rfs.logger().InfoContext(ctx, "cached asset changed, downloading it again", "asset", a.Name())
+	fresh := &AssetFile{
+		DataStream:   resp.Body,
+		FileInfo:     a.FileInfo,
+		URL:          a.URL,
+		APIURL:       a.APIURL,
+		DisplayPath:  a.DisplayPath,
+		ID:           a.ID,
+		NodeID:       a.NodeID,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	if mc := rfs.memoryCache(); mc != nil {
+		mc.delete(a.Name())
+	}
+	if err := rfs.cacheAsset(ctx, fresh); err != nil {
+		return err
+	}
+	a.CacheDigest, a.ETag, a.LastModified = fresh.CacheDigest, fresh.ETag, fresh.LastModified
+	return rfs.writeReleaseData()
+}
+
+// isCached returns true if the disk cache or the cache backend hold a copy
+// of the asset.
+func (rfs *ReleaseFileSystem) isCached(a *AssetFile) bool {
+	if rfs.Options.CacheBackend != nil {
+		key, err := rfs.backendKey(a.Name())
+		if err != nil {
+			return false
+		}
+		_, err = rfs.Options.CacheBackend.Stat(key)
+		return err == nil
+	}
+
+	path, err := rfs.cachedPath(a.Name())
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
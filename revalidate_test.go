@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevalidate(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		backend bool
+	}{
+		{"disk", false},
+		{"backend", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var mtx sync.Mutex
+			data, etag := "v1", `"one"`
+			downloads := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mtx.Lock()
+				defer mtx.Unlock()
+				if r.Header.Get("If-None-Match") == etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				downloads++
+				w.Header().Set("ETag", etag)
+				w.Header().Set("Last-Modified", "Wed, 01 Oct 2025 10:00:00 GMT")
+				io.WriteString(w, data) //nolint:errcheck,gosec
+			}))
+			t.Cleanup(srv.Close)
+
+			rfs := &ReleaseFileSystem{Options: defaultOptions}
+			rfs.Options.CachePath = t.TempDir()
+			backend := &memoryBackend{files: map[string][]byte{}}
+			if tc.backend {
+				require.NoError(t, WithCacheBackend(backend)(&rfs.Options))
+			}
+			require.NoError(t, WithRevalidate(true)(&rfs.Options))
+			rfs.Release.Assets = []*AssetFile{
+				{URL: srv.URL + "/notes.txt", FileInfo: FileInfo{IName: "notes.txt", ISize: 2}},
+			}
+			rfs.indexAssets()
+			require.NoError(t, rfs.CacheRelease())
+			require.Equal(t, `"one"`, rfs.Release.Assets[0].ETag)
+			require.Equal(t, "Wed, 01 Oct 2025 10:00:00 GMT", rfs.Release.Assets[0].LastModified)
+
+			read := func() string {
+				t.Helper()
+				f, err := rfs.Open("notes.txt")
+				require.NoError(t, err)
+				defer f.Close() //nolint:errcheck
+				got, err := io.ReadAll(f)
+				require.NoError(t, err)
+				return string(got)
+			}
+			cached := func() string {
+				t.Helper()
+				if tc.backend {
+					backend.mtx.Lock()
+					defer backend.mtx.Unlock()
+					return string(backend.files["notes.txt"])
+				}
+				got, err := os.ReadFile(filepath.Join(rfs.Options.CachePath, "notes.txt"))
+				require.NoError(t, err)
+				return string(got)
+			}
+
+			// Unchanged assets are served from the cache
+			require.Equal(t, "v1", read())
+			require.Equal(t, 1, downloads)
+
+			// Changed assets are downloaded again and the cache updated
+			mtx.Lock()
+			data, etag = "v2", `"two"`
+			mtx.Unlock()
+			require.Equal(t, "v2", read())
+			require.Equal(t, 2, downloads)
+			require.Equal(t, `"two"`, rfs.Release.Assets[0].ETag)
+			require.Equal(t, "v2", cached())
+
+			require.Equal(t, "v2", read())
+			require.Equal(t, 2, downloads)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SanitizeName is the default filename sanitizer. It rejects asset names
+// that could escape the directory they are written to: empty names, the
+// . and .. elements, absolute paths and names containing path separators
+// or NUL bytes.
+func SanitizeName(name string) (string, error) {
+	switch {
+	case name == "", name == ".", name == "..":
+		return "", fmt.Errorf("%w: %q", ErrUnsafeName, name)
+	case strings.ContainsAny(name, `/\`+"\x00"):
+		return "", fmt.Errorf("%w: %q contains a path separator", ErrUnsafeName, name)
+	case filepath.IsAbs(name), filepath.VolumeName(name) != "":
+		return "", fmt.Errorf("%w: %q is an absolute path", ErrUnsafeName, name)
+	}
+	return name, nil
+}
+
+// localPath returns the path where the data of the named asset is stored
+// under dir. The name is passed through the configured sanitizer and the
+// result is rejected if it is not local to dir.
+func (rfs *ReleaseFileSystem) localPath(dir, name string) (string, error) {
+	sanitize := rfs.Options.NameSanitizer
+	if sanitize == nil {
+		sanitize = SanitizeName
+	}
+
+	clean, err := sanitize(name)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsLocal(clean) {
+		return "", fmt.Errorf("%w: %q resolves outside of %s", ErrUnsafeName, name, dir)
+	}
+	return filepath.Join(dir, clean), nil
+}
+
+// cachedPath returns the path of the named asset in the cache directory.
+func (rfs *ReleaseFileSystem) cachedPath(name string) (string, error) {
+	return rfs.localPath(rfs.cacheDir(), name)
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeName(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		mustErr bool
+	}{
+		{"tool.tar.gz", false},
+		{"..tool", false},
+		{"", true},
+		{".", true},
+		{"..", true},
+		{"../../etc/cron.d/x", true},
+		{"/etc/passwd", true},
+		{`..\..\windows\x`, true},
+		{"dir/file", true},
+		{"nul\x00byte", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			res, err := SanitizeName(tc.name)
+			if tc.mustErr {
+				require.ErrorIs(t, err, ErrUnsafeName)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.name, res)
+		})
+	}
+}
+
+func TestCacheUnsafeNames(t *testing.T) {
+	t.Parallel()
+	adversarial := []string{"../escape.txt", "../../etc/cron.d/x", "/tmp/abs.txt", `..\win.txt`}
+	files := map[string][]byte{"safe.txt": []byte("safe")}
+	for _, name := range adversarial {
+		files[name] = []byte("evil")
+	}
+
+	for _, tc := range []struct {
+		name      string
+		sanitizer func(string) (string, error)
+		expected  []string
+		mustErr   bool
+	}{
+		{"default", nil, []string{"safe.txt"}, true},
+		{
+			"custom",
+			func(s string) (string, error) {
+				return strings.TrimLeft(strings.ReplaceAll(s, "/", "_"), "./"), nil
+			},
+			[]string{"_.._etc_cron.d_x", "_tmp_abs.txt", "_escape.txt", "safe.txt", `\win.txt`},
+			false,
+		},
+		{
+			"custom-escaping",
+			func(s string) (string, error) { return "../" + s, nil },
+			[]string{},
+			true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			root := t.TempDir()
+			rfs, _ := newRemoteTestFS(t, files)
+			rfs.Options.CachePath = filepath.Join(root, "cache", "release")
+			require.NoError(t, os.MkdirAll(rfs.Options.CachePath, 0o755))
+			require.NoError(t, WithNameSanitizer(tc.sanitizer)(&rfs.Options))
+
+			err := rfs.cacheAssets(context.Background(), rfs.Release.Assets)
+			if tc.mustErr {
+				require.ErrorIs(t, err, ErrUnsafeName)
+			} else {
+				require.NoError(t, err)
+			}
+
+			// Nothing may be written outside of the cache directory
+			entries, err := os.ReadDir(root)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			entries, err = os.ReadDir(filepath.Join(root, "cache"))
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+
+			entries, err = os.ReadDir(rfs.Options.CachePath)
+			require.NoError(t, err)
+			names := []string{}
+			for _, e := range entries {
+				if e.Name() != rfs.cacheMetadataName() {
+					names = append(names, e.Name())
+				}
+			}
+			require.ElementsMatch(t, tc.expected, names)
+
+			rfs.Options.Cache = true
+			_, err = rfs.openCachedFile(context.Background(), "../escape.txt")
+			if tc.sanitizer == nil {
+				require.ErrorIs(t, err, ErrUnsafeName)
+			}
+		})
+	}
+}
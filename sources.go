@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+// IsEmpty returns true if the release has no assets.
+func (rfs *ReleaseFileSystem) IsEmpty() bool {
+	if err := rfs.ensureLoaded(); err != nil {
+		return true
+	}
+
+	return len(rfs.Release.Assets) == 0
+}
+
+// addSourceArchives appends the source code archives of the release to
+// its assets. Their size is unknown (-1) until they are downloaded.
+func (rfs *ReleaseFileSystem) addSourceArchives(data *ReleaseData) {
+	for _, archive := range []struct{ ext, url string }{
+		{".tar.gz", data.TarballURL},
+		{".zip", data.ZipballURL},
+	} {
+		if archive.url == "" {
+			continue
+		}
+		data.Assets = append(data.Assets, &AssetFile{
+			URL: archive.url,
+			FileInfo: FileInfo{
+				IName: rfs.Options.Repository + "-" + data.Tag + archive.ext,
+				ISize: -1,
+				Ctime: data.CreatedAt,
+				Mtime: data.PublishedAt,
+			},
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/carabiner-dev/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmptyRelease(t *testing.T) {
+	t.Parallel()
+	const (
+		tarball = "https://api.github.com/repos/example/repo/tarball/v1.0.0"
+		zipball = "https://api.github.com/repos/example/repo/zipball/v1.0.0"
+	)
+	for _, tc := range []struct {
+		name    string
+		sources bool
+		expect  []string
+		warning bool
+	}{
+		{"empty", false, []string{}, true},
+		{"source-archives", true, []string{"repo-v1.0.0.tar.gz", "repo-v1.0.0.zip"}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			caller := &fakeCaller{responses: map[string]fakeResponse{
+				"repos/example/repo/releases/tags/v1.0.0": {
+					http.StatusOK,
+					`{"tag_name":"v1.0.0","assets":[],"tarball_url":"` + tarball + `","zipball_url":"` + zipball + `"}`,
+				},
+				tarball: {http.StatusOK, "tarball data"},
+			}}
+			client, err := github.NewClient(github.WithCaller(caller))
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			rfs := &ReleaseFileSystem{
+				Options: Options{
+					Organization: "example", Repository: "repo", Tag: "v1.0.0",
+					SourceArchives: tc.sources, Logger: slog.New(slog.NewTextHandler(&buf, nil)),
+				},
+				client:      client,
+				assetCaller: caller,
+			}
+			require.NoError(t, rfs.LoadRelease())
+			require.Equal(t, !tc.sources, rfs.IsEmpty())
+			require.Equal(t, tc.warning, bytes.Contains(buf.Bytes(), []byte("release has no assets")))
+
+			entries, err := fs.ReadDir(rfs, ".")
+			require.NoError(t, err)
+			names := []string{}
+			for _, e := range entries {
+				names = append(names, e.Name())
+			}
+			require.Equal(t, tc.expect, names)
+
+			if tc.sources {
+				data, err := fs.ReadFile(rfs, "repo-v1.0.0.tar.gz")
+				require.NoError(t, err)
+				require.Equal(t, "tarball data", string(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// CacheSyncReport lists the cached files changed by SyncCache.
+type CacheSyncReport struct {
+	Added   []string // Assets downloaded because they were not cached
+	Updated []string // Assets downloaded again because their data changed
+	Removed []string // Files deleted because they match no asset
+}
+
+// SyncCache reconciles the cache directory with the release assets. Cacheable
+// assets missing from the cache are downloaded, cached files whose size or
+// digest no longer match their asset are downloaded again and files that
+// don't correspond to any asset are deleted. The release data sidecar is
+// rewritten and kept.
+//
+// The report lists the files changed, even those whose download failed.
+// Failures are returned joined in the error.
+func (rfs *ReleaseFileSystem) SyncCache(ctx context.Context) (CacheSyncReport, error) {
+	if err := rfs.ensureLoaded(); err != nil {
+		return CacheSyncReport{}, err
+	}
+
+	report := CacheSyncReport{}
+	if rfs.Options.CacheBackend != nil {
+		return report, errors.New("unable to sync cache, only the disk cache can be synced")
+	}
+	if rfs.Options.CachePath == "" {
+		return report, errors.New("unable to sync cache, release cache path not set")
+	}
+
+	dir := rfs.cacheDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return report, &CacheError{Path: dir, Err: err}
+	}
+
+	// Collect the files currently in the cache
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return report, fmt.Errorf("reading cache directory: %w", err)
+	}
+	cached := map[string]os.DirEntry{}
+	for _, e := range entries {
+		if e.Type().IsRegular() && e.Name() != rfs.cacheMetadataName() {
+			cached[e.Name()] = e
+		}
+	}
+
+	download := []*AssetFile{}
+	for _, a := range rfs.Release.Assets {
+		path, err := rfs.cachedPath(a.Name())
+		if err != nil {
+			continue // Unsafe names are never written to the cache
+		}
+		e, ok := cached[filepath.Base(path)]
+		delete(cached, filepath.Base(path))
+		if !rfs.cacheable(a) {
+			continue
+		}
+
+		if !ok {
+			report.Added = append(report.Added, a.Name())
+			download = append(download, a)
+			continue
+		}
+
+		if rfs.cachedFileCurrent(a, path, e) {
+			continue
+		}
+		report.Updated = append(report.Updated, a.Name())
+		download = append(download, a)
+		if mc := rfs.memoryCache(); mc != nil {
+			mc.delete(a.Name())
+		}
+	}
+
+	// Whatever is left does not belong to any asset
+	errs := []error{}
+	for name := range cached {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("removing cached %q: %w", name, err))
+			continue
+		}
+		report.Removed = append(report.Removed, name)
+	}
+
+	slices.Sort(report.Added)
+	slices.Sort(report.Updated)
+	slices.Sort(report.Removed)
+
+	if err := rfs.writeReleaseData(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := rfs.cacheAssets(ctx, download); err != nil {
+		errs = append(errs, err)
+	}
+	rfs.Options.Cache = true
+
+	rfs.logger().InfoContext(
+		ctx, "synced cache", "added", len(report.Added), "updated", len(report.Updated), "removed", len(report.Removed),
+	)
+	return report, errors.Join(errs...)
+}
+
+// cachedFileCurrent returns true if the cached file at path holds the
+// current data of the asset. Files that don't match are removed, except
+// partial downloads that a resumable cache can complete.
+func (rfs *ReleaseFileSystem) cachedFileCurrent(a *AssetFile, path string, e os.DirEntry) bool {
+	info, err := e.Info()
+	if err == nil && (a.Size() <= 0 || info.Size() == a.Size()) {
+		rfs.verifiedDigests.Delete(path)
+		rfs.verifiedFiles.Delete(path)
+		if rfs.verifyCachedDigest(a, path) == nil {
+			return true
+		}
+	}
+
+	if err == nil && rfs.Options.ResumableCache && info.Size() < a.Size() {
+		return false
+	}
+	os.Remove(path) //nolint:errcheck,gosec
+	return false
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncCache(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{
+		"new.txt":     []byte("new"),
+		"changed.txt": []byte("changed"),
+		"current.txt": []byte("current"),
+	}
+	rfs, caller := newRemoteTestFS(t, files)
+	rfs.Options.CachePath = t.TempDir()
+
+	for name, data := range map[string]string{
+		"changed.txt":           "old",
+		"current.txt":           "current",
+		"renamed.txt":           "gone",
+		rfs.cacheMetadataName(): "{}",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(rfs.Options.CachePath, name), []byte(data), 0o600))
+	}
+
+	report, err := rfs.SyncCache(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, CacheSyncReport{
+		Added:   []string{"new.txt"},
+		Updated: []string{"changed.txt"},
+		Removed: []string{"renamed.txt"},
+	}, report)
+	require.Len(t, caller.requests, 2)
+
+	for name, data := range files {
+		got, err := os.ReadFile(filepath.Join(rfs.Options.CachePath, name))
+		require.NoError(t, err)
+		require.Equal(t, data, got)
+	}
+	require.NoFileExists(t, filepath.Join(rfs.Options.CachePath, "renamed.txt"))
+	require.FileExists(t, filepath.Join(rfs.Options.CachePath, rfs.cacheMetadataName()))
+
+	// A second sync finds nothing to do
+	report, err = rfs.SyncCache(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, CacheSyncReport{}, report)
+
+	_, err = (&ReleaseFileSystem{Options: defaultOptions}).SyncCache(context.Background())
+	require.Error(t, err)
+}
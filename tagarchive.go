@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const tagRefURLMask = `repos/%s/%s/git/ref/tags/%s`
+
+// fetchTagArchive returns release data for a tag that has no release. The
+// only asset is the source archive of the tag, which is expanded into the
+// filesystem. notFoundErr is returned if the tag does not exist either.
+func (rfs *ReleaseFileSystem) fetchTagArchive(ctx context.Context, notFoundErr error) (ReleaseData, error) {
+	exists, err := rfs.tagExists(ctx)
+	if err != nil {
+		return ReleaseData{}, fmt.Errorf("%w (looking for tag: %w)", notFoundErr, err)
+	}
+	if !exists {
+		return ReleaseData{}, fmt.Errorf("%w (tag not found)", notFoundErr)
+	}
+
+	tag := rfs.Options.Tag
+	rfs.logger().InfoContext(ctx, "release not found, using the tag source archive", "release", rfs.releaseRef())
+
+	webHost := rfs.Options.Host
+	if webHost == githubAPIURL {
+		webHost = "github.com"
+	}
+
+	name := rfs.Options.Repository + "-" + tag + ".tar.gz"
+	if !slices.Contains(rfs.Options.ExpandArchives, name) {
+		rfs.Options.ExpandArchives = append(slices.Clone(rfs.Options.ExpandArchives), name)
+	}
+
+	return ReleaseData{
+		Tag:  tag,
+		Name: tag,
+		Assets: []*AssetFile{{
+			URL: fmt.Sprintf(
+				"https://%s/%s/%s/archive/refs/tags/%s.tar.gz",
+				webHost, rfs.Options.Organization, rfs.Options.Repository, escapeRef(tag),
+			),
+			FileInfo: FileInfo{IName: name, ISize: -1},
+		}},
+	}, nil
+}
+
+// tagExists checks if the configured tag exists in the repository.
+func (rfs *ReleaseFileSystem) tagExists(ctx context.Context) (bool, error) {
+	ctx, cancel := withTimeout(ctx, rfs.Options.Timeout)
+	defer cancel()
+
+	resp, err := rfs.client.Call(ctx, "GET", fmt.Sprintf(
+		tagRefURLMask, rfs.Options.Organization, rfs.Options.Repository, escapeRef(rfs.Options.Tag),
+	), nil)
+	if resp != nil {
+		defer resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// escapeRef escapes a git ref for use in a URL path. The segments of
+// namespaced refs like release/v1 are escaped separately to keep the
+// slashes, which GitHub expects unescaped.
+func escapeRef(ref string) string {
+	segments := strings.Split(ref, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
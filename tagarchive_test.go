@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"io/fs"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFallbackToTagArchive(t *testing.T) {
+	t.Parallel()
+	const archiveURL = "https://github.com/example/repo/archive/refs/tags/v1.0.0.tar.gz"
+	archive := tarGz(t, []string{"repo-1.0.0/", "repo-1.0.0/README.md"}, map[string]string{
+		"repo-1.0.0/README.md": "# repo",
+	})
+
+	for _, tc := range []struct {
+		name      string
+		fallback  bool
+		tagExists bool
+		mustErr   bool
+	}{
+		{"disabled", false, true, true},
+		{"tag-archive", true, true, false},
+		{"no-tag", true, false, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			caller := &fakeCaller{responses: map[string]fakeResponse{
+				archiveURL: {http.StatusOK, string(archive)},
+			}}
+			if tc.tagExists {
+				caller.responses["repos/example/repo/git/ref/tags/v1.0.0"] = fakeResponse{
+					http.StatusOK, `{"ref": "refs/tags/v1.0.0"}`,
+				}
+			}
+			rfs := &ReleaseFileSystem{Options: defaultOptions, client: caller, assetCaller: caller}
+			rfs.Options.Organization = "example"
+			rfs.Options.Repository = "repo"
+			rfs.Options.Tag = "v1.0.0"
+			rfs.Options.FallbackToTagArchive = tc.fallback
+
+			err := rfs.LoadRelease()
+			if tc.mustErr {
+				require.ErrorIs(t, err, ErrReleaseNotFound)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "v1.0.0", rfs.ResolvedTag())
+
+			data, err := fs.ReadFile(rfs, "repo-v1.0.0.tar.gz/repo-1.0.0/README.md")
+			require.NoError(t, err)
+			require.Equal(t, "# repo", string(data))
+		})
+	}
+}
+
+func TestTagArchiveNamespacedTag(t *testing.T) {
+	t.Parallel()
+	caller := &fakeCaller{responses: map[string]fakeResponse{
+		"repos/example/repo/git/ref/tags/release/v1": {http.StatusOK, `{"ref": "refs/tags/release/v1"}`},
+	}}
+	rfs := &ReleaseFileSystem{Options: defaultOptions, client: caller, assetCaller: caller}
+	rfs.Options.Organization = "example"
+	rfs.Options.Repository = "repo"
+	rfs.Options.Tag = "release/v1"
+	rfs.Options.FallbackToTagArchive = true
+
+	require.NoError(t, rfs.LoadRelease())
+	require.Contains(t, caller.requests, "repos/example/repo/git/ref/tags/release/v1")
+	require.Len(t, rfs.Release.Assets, 1)
+	require.Equal(t, "https://github.com/example/repo/archive/refs/tags/release/v1.tar.gz", rfs.Release.Assets[0].URL)
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of the spans created by ghrfs
+const tracerName = "github.com/carabiner-dev/ghrfs"
+
+// Attribute keys set on the spans
+const (
+	attrOrganization = attribute.Key("github.organization")
+	attrRepository   = attribute.Key("github.repository")
+	attrTag          = attribute.Key("github.release.tag")
+	attrAsset        = attribute.Key("ghrfs.asset.name")
+	attrAssetCount   = attribute.Key("ghrfs.asset.count")
+	attrBytes        = attribute.Key("ghrfs.bytes")
+)
+
+// tracer returns the tracer from the configured provider, a no-op tracer
+// if none is set.
+func (rfs *ReleaseFileSystem) tracer() trace.Tracer {
+	tp := rfs.Options.TracerProvider
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+// startSpan starts a span annotated with the release coordinates.
+func (rfs *ReleaseFileSystem) startSpan(
+	ctx context.Context, name string, attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	tag := rfs.Release.Tag
+	if tag == "" {
+		tag = rfs.Options.Tag
+	}
+	return rfs.tracer().Start(ctx, name, trace.WithAttributes(append([]attribute.KeyValue{
+		attrOrganization.String(rfs.Options.Organization),
+		attrRepository.String(rfs.Options.Repository),
+		attrTag.String(tag),
+	}, attrs...)...))
+}
+
+// endSpan ends a span, recording err if it is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// spanStream ends a span when the stream is closed, recording the number
+// of bytes read.
+type spanStream struct {
+	io.ReadCloser
+	span trace.Span
+	n    int64
+	once sync.Once
+}
+
+func (ss *spanStream) Read(p []byte) (int, error) {
+	n, err := ss.ReadCloser.Read(p)
+	ss.n += int64(n)
+	return n, err
+}
+
+func (ss *spanStream) Close() error {
+	err := ss.ReadCloser.Close()
+	ss.once.Do(func() {
+		ss.span.SetAttributes(attrBytes.Int64(ss.n))
+		endSpan(ss.span, err)
+	})
+	return err
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordingProvider is a tracer provider that records the spans started
+type recordingProvider struct {
+	embedded.TracerProvider
+	mtx   sync.Mutex
+	spans []*recordedSpan
+}
+
+func (rp *recordingProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{provider: rp}
+}
+
+// find returns the spans with a name
+func (rp *recordingProvider) find(name string) []*recordedSpan {
+	rp.mtx.Lock()
+	defer rp.mtx.Unlock()
+	ret := []*recordedSpan{}
+	for _, s := range rp.spans {
+		if s.name == name {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+type recordingTracer struct {
+	embedded.Tracer
+	provider *recordingProvider
+}
+
+func (rt *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recordedSpan{name: name, attrs: map[attribute.Key]attribute.Value{}}
+	cfg := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(cfg.Attributes()...)
+	rt.provider.mtx.Lock()
+	rt.provider.spans = append(rt.provider.spans, span)
+	rt.provider.mtx.Unlock()
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordedSpan struct {
+	noop.Span
+	mtx   sync.Mutex
+	name  string
+	attrs map[attribute.Key]attribute.Value
+	err   error
+	ended bool
+}
+
+func (rs *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	for _, a := range kv {
+		rs.attrs[a.Key] = a.Value
+	}
+}
+
+func (rs *recordedSpan) RecordError(err error, _ ...trace.EventOption) {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	rs.err = err
+}
+
+func (rs *recordedSpan) End(...trace.SpanEndOption) {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	rs.ended = true
+}
+
+func TestTracing(t *testing.T) {
+	t.Parallel()
+	t.Run("load", func(t *testing.T) {
+		t.Parallel()
+		tp := &recordingProvider{}
+		caller := &fakeCaller{responses: map[string]fakeResponse{
+			"repos/example/repo/releases/latest": {http.StatusOK, `{"id": 1, "tag_name": "v1.0.0", "assets": [{"name": "a.txt"}]}`},
+		}}
+		rfs := &ReleaseFileSystem{Options: defaultOptions, client: caller}
+		rfs.Options.Organization = "example"
+		rfs.Options.Repository = "repo"
+		require.NoError(t, WithTracerProvider(tp)(&rfs.Options))
+		require.NoError(t, rfs.LoadRelease())
+
+		spans := tp.find("ghrfs.LoadRelease")
+		require.Len(t, spans, 1)
+		require.True(t, spans[0].ended)
+		require.Equal(t, "example", spans[0].attrs[attrOrganization].AsString())
+		require.Equal(t, "repo", spans[0].attrs[attrRepository].AsString())
+		require.Equal(t, "v1.0.0", spans[0].attrs[attrTag].AsString())
+		require.Equal(t, int64(1), spans[0].attrs[attrAssetCount].AsInt64())
+
+		// Failures are recorded in the span
+		rfs.Options.Tag = "v9.9.9"
+		require.Error(t, rfs.LoadRelease())
+		spans = tp.find("ghrfs.LoadRelease")
+		require.Len(t, spans, 2)
+		require.Error(t, spans[1].err)
+	})
+
+	t.Run("cache", func(t *testing.T) {
+		t.Parallel()
+		tp := &recordingProvider{}
+		rfs, caller := newRemoteTestFS(t, map[string][]byte{"a.txt": []byte("hello"), "gone.txt": []byte("gone")})
+		delete(caller.responses, "https://github.com/example/repo/releases/download/v1.0.0/gone.txt")
+		rfs.Options.CachePath = t.TempDir()
+		require.NoError(t, WithTracerProvider(tp)(&rfs.Options))
+		require.Error(t, rfs.CacheRelease())
+
+		for _, name := range []string{"ghrfs.CacheAsset", "ghrfs.OpenRemoteFile"} {
+			spans := tp.find(name)
+			require.Len(t, spans, 2, name)
+			for _, s := range spans {
+				require.True(t, s.ended, name)
+				switch s.attrs[attrAsset].AsString() {
+				case "a.txt":
+					require.NoError(t, s.err)
+					require.Equal(t, int64(5), s.attrs[attrBytes].AsInt64())
+				case "gone.txt":
+					require.Error(t, s.err)
+				default:
+					require.Fail(t, "unexpected asset", name)
+				}
+			}
+		}
+	})
+}
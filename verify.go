@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// AssetVerifier verifies the data of a release asset against its signature
+// before it is served or cached. Implementations can check Sigstore bundles,
+// cosign signatures or any other signing scheme.
+//
+// Verify reads the whole asset before the caller gets any data. Remote
+// assets are spooled to a temporary file for it, so verifying large assets
+// costs disk space rather than memory.
+type AssetVerifier interface {
+	// Verify checks data against the signature read from sig. When the
+	// release has no signature asset for the file, sig is nil.
+	Verify(name string, data io.Reader, sig io.Reader) error
+}
+
+// NoopVerifier is an AssetVerifier that accepts all assets. It is the
+// default verifier and it disables verification entirely.
+type NoopVerifier struct{}
+
+// Verify always returns nil
+func (NoopVerifier) Verify(string, io.Reader, io.Reader) error {
+	return nil
+}
+
+// defaultSignatureSuffixes are the suffixes appended to an asset name to
+// find its signature in the release.
+var defaultSignatureSuffixes = []string{".sigstore.json", ".sigstore", ".sig"}
+
+// VerificationError is returned when an asset fails verification
+type VerificationError struct {
+	Name string
+	Err  error
+}
+
+func (ve *VerificationError) Error() string {
+	return fmt.Sprintf("verification of %q failed: %v", ve.Name, ve.Err)
+}
+
+func (ve *VerificationError) Unwrap() error {
+	return ve.Err
+}
+
+// verifying returns true if a verifier other than the no-op is configured
+func (rfs *ReleaseFileSystem) verifying() bool {
+	switch rfs.Options.Verifier.(type) {
+	case nil, NoopVerifier, *NoopVerifier:
+		return false
+	default:
+		return true
+	}
+}
+
+// isSignature returns true if name looks like a signature asset
+func (rfs *ReleaseFileSystem) isSignature(name string) bool {
+	for _, suffix := range rfs.Options.SignatureSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// signatureFor returns the name of the signature asset of name, or an
+// empty string if the release does not have one.
+func (rfs *ReleaseFileSystem) signatureFor(name string) string {
+	for _, suffix := range rfs.Options.SignatureSuffixes {
+		if _, ok := rfs.Release.fileIndex[name+suffix]; ok {
+			return name + suffix
+		}
+	}
+	return ""
+}
+
+// verifyAsset runs the configured verifier on an open asset file. Seekable
+// streams such as cached files are verified and then rewound. Other streams
+// are spooled to a temporary file so the verified data can be read again
+// without holding it in memory. Signature assets are not verified.
+func (rfs *ReleaseFileSystem) verifyAsset(ctx context.Context, f *AssetFile) error {
+	if !rfs.verifying() || rfs.isSignature(f.Name()) {
+		return nil
+	}
+
+	var sig io.Reader
+	if sigName := rfs.signatureFor(f.Name()); sigName != "" {
+		sf, err := rfs.openAsset(ctx, sigName)
+		if err != nil {
+			return &VerificationError{Name: f.Name(), Err: fmt.Errorf("opening signature: %w", err)}
+		}
+		defer sf.Close() //nolint:errcheck
+		sig = sf
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	stream, ok := f.DataStream.(io.ReadSeeker)
+	if !ok {
+		spool, err := spoolStream(f.DataStream)
+		if err != nil {
+			return fmt.Errorf("reading %q: %w", f.Name(), err)
+		}
+		f.DataStream.Close() //nolint:errcheck,gosec
+		f.DataStream, stream = spool, spool
+	}
+
+	if err := rfs.Options.Verifier.Verify(f.Name(), stream, sig); err != nil {
+		return &VerificationError{Name: f.Name(), Err: err}
+	}
+	if _, err := stream.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("rewinding verified %q: %w", f.Name(), err)
+	}
+	return nil
+}
+
+// verifyOpenedAsset verifies an asset opened to be served. Cached files
+// that passed verification when they were written are not verified again.
+func (rfs *ReleaseFileSystem) verifyOpenedAsset(ctx context.Context, f *AssetFile) error {
+	if f.cachePath != "" {
+		if _, ok := rfs.verifiedFiles.Load(f.cachePath); ok {
+			return nil
+		}
+	}
+	if err := rfs.verifyAsset(ctx, f); err != nil {
+		return err
+	}
+	if f.cachePath != "" && rfs.verifying() {
+		rfs.verifiedFiles.Store(f.cachePath, struct{}{})
+	}
+	return nil
+}
+
+// openVerifiedAsset opens a new handle to the raw data of an asset that
+// passed verification.
+func (rfs *ReleaseFileSystem) openVerifiedAsset(ctx context.Context, name string) (*AssetFile, error) {
+	f, err := rfs.openAsset(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := rfs.verifyOpenedAsset(ctx, f); err != nil {
+		f.Close() //nolint:errcheck,gosec
+		return nil, err
+	}
+	return f, nil
+}
+
+// tempStream is a seekable data stream backed by a temporary file that is
+// removed when the stream is closed.
+type tempStream struct {
+	*os.File
+}
+
+func (ts tempStream) Close() error {
+	err := ts.File.Close()
+	if rerr := os.Remove(ts.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// spoolStream copies the data of r to a temporary file and returns it
+// as a stream rewound to the start of the data.
+func spoolStream(r io.Reader) (io.ReadSeekCloser, error) {
+	f, err := os.CreateTemp("", ".ghrfs-verify-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary file: %w", err)
+	}
+	ts := tempStream{File: f}
+	if _, err := io.Copy(f, r); err != nil {
+		ts.Close() //nolint:errcheck,gosec
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		ts.Close() //nolint:errcheck,gosec
+		return nil, err
+	}
+	return ts, nil
+}
+
+// verifyCachedFile verifies an asset written to the cache, removing the
+// cached file if verification fails.
+func (rfs *ReleaseFileSystem) verifyCachedFile(ctx context.Context, a *AssetFile) error {
+	// The file was just written, check it against the asset digest
+	path, err := rfs.cachedPath(a.Name())
+	if err != nil {
+		return err
+	}
+	rfs.verifiedDigests.Delete(path)
+	rfs.verifiedFiles.Delete(path)
+	if err := rfs.verifyCachedDigest(a, path); err != nil {
+		os.Remove(path) //nolint:errcheck,gosec
+		return err
+	}
+
+	if !rfs.verifying() || rfs.isSignature(a.Name()) {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening cached %q: %w", a.Name(), err)
+	}
+
+	err = rfs.verifyAsset(ctx, &AssetFile{DataStream: f, FileInfo: a.FileInfo})
+	f.Close() //nolint:errcheck,gosec
+	if err != nil {
+		os.Remove(path) //nolint:errcheck,gosec
+		return err
+	}
+	rfs.verifiedFiles.Store(path, struct{}{})
+	return nil
+}
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// digestVerifier checks that the signature is the hex sha256 of the data
+type digestVerifier struct{}
+
+func (digestVerifier) Verify(_ string, data, sig io.Reader) error {
+	if sig == nil {
+		return errors.New("no signature found")
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, data); err != nil {
+		return err
+	}
+	expected, err := io.ReadAll(sig)
+	if err != nil {
+		return err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != string(expected) {
+		return errors.New("digest mismatch")
+	}
+	return nil
+}
+
+func TestVerifier(t *testing.T) {
+	t.Parallel()
+	data := []byte("signed data")
+	sum := sha256.Sum256(data)
+	goodSig := []byte(hex.EncodeToString(sum[:]))
+
+	for _, tc := range []struct {
+		name     string
+		verifier AssetVerifier
+		files    map[string][]byte
+		mustErr  bool
+	}{
+		{"noop", NoopVerifier{}, map[string][]byte{"data.txt": data}, false},
+		{"valid", digestVerifier{}, map[string][]byte{"data.txt": data, "data.txt.sig": goodSig}, false},
+		{"invalid", digestVerifier{}, map[string][]byte{"data.txt": data, "data.txt.sig": []byte("bad")}, true},
+		{"missing-signature", digestVerifier{}, map[string][]byte{"data.txt": data}, true},
+	} {
+		t.Run(tc.name+"/cached", func(t *testing.T) {
+			t.Parallel()
+			rfs := newCachedTestFS(t, tc.files)
+			rfs.Options.Verifier = tc.verifier
+
+			f, err := rfs.Open("data.txt")
+			if tc.mustErr {
+				var verr *VerificationError
+				require.ErrorAs(t, err, &verr)
+				require.Equal(t, "data.txt", verr.Name)
+				return
+			}
+			require.NoError(t, err)
+			got, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.Equal(t, data, got)
+		})
+
+		t.Run(tc.name+"/remote", func(t *testing.T) {
+			t.Parallel()
+			rfs, _ := newRemoteTestFS(t, tc.files)
+			rfs.Options.Verifier = tc.verifier
+
+			f, err := rfs.Open("data.txt")
+			if tc.mustErr {
+				var verr *VerificationError
+				require.ErrorAs(t, err, &verr)
+				return
+			}
+			require.NoError(t, err)
+			got, err := io.ReadAll(f)
+			require.NoError(t, err)
+			require.Equal(t, data, got)
+		})
+
+		t.Run(tc.name+"/cache-release", func(t *testing.T) {
+			t.Parallel()
+			rfs, _ := newRemoteTestFS(t, tc.files)
+			rfs.Options.Verifier = tc.verifier
+			rfs.Options.CachePath = t.TempDir()
+
+			err := rfs.CacheRelease()
+			if tc.mustErr {
+				var verr *VerificationError
+				require.ErrorAs(t, err, &verr)
+				require.NoFileExists(t, filepath.Join(rfs.Options.CachePath, "data.txt"))
+				return
+			}
+			require.NoError(t, err)
+			got, err := os.ReadFile(filepath.Join(rfs.Options.CachePath, "data.txt"))
+			require.NoError(t, err)
+			require.Equal(t, data, got)
+		})
+	}
+}
+
+// countingVerifier counts the verifications of each asset
+type countingVerifier struct {
+	mtx   sync.Mutex
+	calls map[string]int
+}
+
+func (cv *countingVerifier) Verify(name string, data, _ io.Reader) error {
+	cv.mtx.Lock()
+	defer cv.mtx.Unlock()
+	cv.calls[name]++
+	_, err := io.Copy(io.Discard, data)
+	return err
+}
+
+func TestVerifierReuse(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{"data.txt": []byte("signed data")}
+
+	t.Run("cached", func(t *testing.T) {
+		t.Parallel()
+		rfs, _ := newRemoteTestFS(t, files)
+		verifier := &countingVerifier{calls: map[string]int{}}
+		rfs.Options.Verifier = verifier
+		rfs.Options.CachePath = t.TempDir()
+		require.NoError(t, rfs.CacheRelease())
+		rfs.Options.Cache = true
+
+		// Files verified when they were cached are not verified again
+		for range 3 {
+			got, err := fs.ReadFile(rfs, "data.txt")
+			require.NoError(t, err)
+			require.Equal(t, files["data.txt"], got)
+		}
+		require.Equal(t, 1, verifier.calls["data.txt"])
+	})
+
+	t.Run("remote", func(t *testing.T) {
+		t.Parallel()
+		rfs, _ := newRemoteTestFS(t, files)
+		verifier := &countingVerifier{calls: map[string]int{}}
+		rfs.Options.Verifier = verifier
+
+		f, err := rfs.Open("data.txt")
+		require.NoError(t, err)
+
+		// Remote data is spooled to a temporary file removed on close
+		spool, ok := f.(*SeekableAssetFile).DataStream.(tempStream)
+		require.True(t, ok)
+		require.FileExists(t, spool.Name())
+		got, err := io.ReadAll(f)
+		require.NoError(t, err)
+		require.Equal(t, files["data.txt"], got)
+		require.NoError(t, f.Close())
+		require.NoFileExists(t, spool.Name())
+		require.Equal(t, 1, verifier.calls["data.txt"])
+	})
+}
+
+func TestVerifierExport(t *testing.T) {
+	t.Parallel()
+	files := map[string][]byte{"data.txt": []byte("signed data"), "data.txt.sig": []byte("bad")}
+
+	t.Run("extract", func(t *testing.T) {
+		t.Parallel()
+		rfs := newCachedTestFS(t, files)
+		rfs.Options.Verifier = digestVerifier{}
+
+		dest := t.TempDir()
+		var verr *VerificationError
+		require.ErrorAs(t, rfs.Extract(t.Context(), dest), &verr)
+		require.NoFileExists(t, filepath.Join(dest, "data.txt"))
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		t.Parallel()
+		rfs := newCachedTestFS(t, files)
+		rfs.Options.Verifier = digestVerifier{}
+
+		var buf bytes.Buffer
+		var verr *VerificationError
+		require.ErrorAs(t, rfs.WriteArchive(t.Context(), &buf, ArchiveTar), &verr)
+		require.NotContains(t, buf.String(), "signed data")
+	})
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// UnmarshalJSON decodes the release data. Assets that fail to decode are
+// skipped instead of failing the whole release, the errors are available
+// through LoadWarnings once the release is loaded.
+func (rd *ReleaseData) UnmarshalJSON(data []byte) error {
+	type releaseData ReleaseData
+	raw := struct {
+		*releaseData
+		Assets []json.RawMessage `json:"assets"`
+	}{releaseData: (*releaseData)(rd)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	rd.Assets = make([]*AssetFile, 0, len(raw.Assets))
+	rd.warnings = nil
+	for i, assetData := range raw.Assets {
+		a := &AssetFile{}
+		if err := json.Unmarshal(assetData, a); err != nil {
+			rd.warnings = append(rd.warnings, fmt.Errorf("decoding asset #%d: %w", i, err))
+			continue
+		}
+		rd.Assets = append(rd.Assets, a)
+	}
+	return nil
+}
+
+// LoadWarnings returns the errors of the malformed assets skipped when
+// loading the release.
+func (rfs *ReleaseFileSystem) LoadWarnings() []error {
+	if err := rfs.ensureLoaded(); err != nil {
+		return nil
+	}
+
+	return slices.Clone(rfs.Release.warnings)
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package ghrfs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadWarnings(t *testing.T) {
+	t.Parallel()
+	const endpoint = "repos/example/repo/releases/tags/v1.0.0"
+	for _, tc := range []struct {
+		name     string
+		body     string
+		mustErr  bool
+		assets   []string
+		warnings int
+	}{
+		{
+			"valid", `{"tag_name": "v1.0.0", "assets": [{"name": "a.txt", "size": 1}, {"name": "b.txt", "size": 2}]}`,
+			false, []string{"a.txt", "b.txt"}, 0,
+		},
+		{
+			"malformed-asset",
+			`{"tag_name": "v1.0.0", "assets": [{"name": "a.txt", "size": "big"}, {"name": "b.txt", "size": 2}, {"name": 3}]}`,
+			false, []string{"b.txt"}, 2,
+		},
+		{
+			"malformed-release", `{"tag_name": 1, "assets": []}`, true, nil, 0,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rfs := &ReleaseFileSystem{
+				Options: defaultOptions,
+				client:  &fakeCaller{responses: map[string]fakeResponse{endpoint: {http.StatusOK, tc.body}}},
+			}
+			rfs.Options.Organization = "example"
+			rfs.Options.Repository = "repo"
+			rfs.Options.Tag = "v1.0.0"
+
+			err := rfs.LoadRelease()
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, rfs.LoadWarnings(), tc.warnings)
+
+			names := []string{}
+			for _, a := range rfs.Release.Assets {
+				names = append(names, a.Name())
+			}
+			require.Equal(t, tc.assets, names)
+		})
+	}
+}